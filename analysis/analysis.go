@@ -0,0 +1,175 @@
+// Package analysis вычисляет статистику текста песни: объём, разнообразие словаря, повторяющиеся строки,
+// схемы рифмовки частей и оценку времени чтения и исполнения.
+package analysis
+
+import (
+	"go-tunes/models"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// readingWordsPerMinute — средняя скорость чтения про себя
+	readingWordsPerMinute = 200
+	// singingSyllablesPerSecond — средний темп пения; паузы между строками не учитываются
+	singingSyllablesPerSecond = 2
+	// maxRepeatedLines ограничивает список повторяющихся строк
+	maxRepeatedLines = 10
+)
+
+// vowels — гласные латиницы и кириллицы, по которым считаются слоги и выделяются рифменные окончания
+const vowels = "aeiouyàáâãäåæèéêëìíîïòóôõöøœùúûüýÿąęаеёиоуыэюяіїє"
+
+// Analyze вычисляет статистику текста, разобранного на части. Повторы частей (например, припева) учитываются
+// так же, как при исполнении: их слова и строки входят в подсчёт.
+func Analyze(sections models.LyricSections) models.LyricsAnalysis {
+	result := models.LyricsAnalysis{
+		Sections:      len(sections),
+		SectionTypes:  make(map[models.SectionType]int),
+		RepeatedLines: []models.RepeatedLine{},
+		RhymeSchemes:  make([]models.RhymeScheme, 0, len(sections)),
+	}
+
+	vocabulary := make(map[string]bool)
+	lineCounts := make(map[string]int)
+	lineText := make(map[string]string) // Нормализованная строка -> первое написание
+	var lineOrder []string
+	for i, section := range sections {
+		result.SectionTypes[section.Type]++
+		for _, line := range section.Lines {
+			words := Words(line)
+			if len(words) == 0 {
+				continue
+			}
+			result.Lines++
+			result.Words += len(words)
+			for _, word := range words {
+				vocabulary[word] = true
+				result.Syllables += Syllables(word)
+			}
+			key := strings.Join(words, " ")
+			if lineCounts[key] == 0 {
+				lineText[key] = strings.TrimSpace(line)
+				lineOrder = append(lineOrder, key)
+			}
+			lineCounts[key]++
+		}
+		result.RhymeSchemes = append(result.RhymeSchemes, models.RhymeScheme{
+			Index:  i + 1,
+			Type:   section.Type,
+			Label:  section.Label,
+			Scheme: Scheme(section.Lines),
+		})
+	}
+
+	result.UniqueWords = len(vocabulary)
+	if result.Words > 0 {
+		result.UniqueWordRatio = round(float64(result.UniqueWords)/float64(result.Words), 3)
+	}
+	for _, key := range lineOrder {
+		if lineCounts[key] > 1 {
+			result.RepeatedLines = append(result.RepeatedLines, models.RepeatedLine{Text: lineText[key], Count: lineCounts[key]})
+		}
+	}
+	sort.SliceStable(result.RepeatedLines, func(i, j int) bool { return result.RepeatedLines[i].Count > result.RepeatedLines[j].Count })
+	if len(result.RepeatedLines) > maxRepeatedLines {
+		result.RepeatedLines = result.RepeatedLines[:maxRepeatedLines]
+	}
+	result.ReadingTimeSeconds = round(float64(result.Words)*60/readingWordsPerMinute, 1)
+	result.SingingTimeSeconds = round(float64(result.Syllables)/singingSyllablesPerSecond, 1)
+	return result
+}
+
+// Words разбивает строку на слова в нижнем регистре; апостроф внутри слова (don't) слово не разделяет
+func Words(line string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+	words := fields[:0]
+	for _, field := range fields {
+		if word := strings.Trim(field, "'’"); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Syllables оценивает количество слогов слова по группам гласных; у слова без гласных один слог
+func Syllables(word string) int {
+	count := len(vowelGroups([]rune(word)))
+	return max(count, 1)
+}
+
+// vowelGroups возвращает позиции начала групп подряд идущих гласных
+func vowelGroups(runes []rune) []int {
+	var groups []int
+	inside := false
+	for i, r := range runes {
+		isVowel := strings.ContainsRune(vowels, r)
+		if isVowel && !inside {
+			groups = append(groups, i)
+		}
+		inside = isVowel
+	}
+	return groups
+}
+
+// rhymeKey возвращает окончание последнего слова строки начиная с последней группы гласных.
+// Окончание из одной гласной (открытый слог: "облака", "траве") дополняется предшествующей согласной,
+// чтобы "река" рифмовалась с "облака", но не с "трава".
+func rhymeKey(line string) string {
+	words := Words(line)
+	if len(words) == 0 {
+		return ""
+	}
+	runes := []rune(words[len(words)-1])
+	groups := vowelGroups(runes)
+	if len(groups) == 0 {
+		return string(runes)
+	}
+	start := groups[len(groups)-1]
+	if len(runes)-start == 1 && start > 0 {
+		start--
+	}
+	return string(runes[start:])
+}
+
+// Scheme оценивает схему рифмовки строк: строке с тем же рифменным окончанием, что у одной из предыдущих,
+// назначается её буква, остальным — следующая свободная буква. Строки без слов обозначаются дефисом.
+func Scheme(lines []string) string {
+	var scheme strings.Builder
+	keys := make([]string, 0, len(lines))
+	letters := make([]rune, 0, len(lines))
+	next := 'A'
+	for _, line := range lines {
+		key := rhymeKey(line)
+		if key == "" {
+			scheme.WriteRune('-')
+			continue
+		}
+		letter := rune(0)
+		for i := len(keys) - 1; i >= 0; i-- {
+			if keys[i] == key {
+				letter = letters[i]
+				break
+			}
+		}
+		if letter == 0 {
+			letter = next
+			if next < 'Z' {
+				next++
+			}
+		}
+		keys = append(keys, key)
+		letters = append(letters, letter)
+		scheme.WriteRune(letter)
+	}
+	return scheme.String()
+}
+
+func round(value float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	return math.Round(value*scale) / scale
+}
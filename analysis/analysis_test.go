@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"go-tunes/models"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"Ooh baby, don't you know I suffer?", []string{"ooh", "baby", "don't", "you", "know", "i", "suffer"}},
+		{"'Cause I’m  — 'free'", []string{"cause", "i’m", "free"}},
+		{"Звезда по имени Солнце", []string{"звезда", "по", "имени", "солнце"}},
+	}
+	for _, tt := range tests {
+		if got := Words(tt.line); !slices.Equal(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"hmm", 1},
+		{"baby", 2},
+		{"suffer", 2},
+		{"звезда", 2},
+		{"солнце", 2},
+		{"облака", 3},
+	}
+	for _, tt := range tests {
+		if got := Syllables(tt.word); got != tt.want {
+			t.Errorf("Syllables(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestScheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"empty", nil, ""},
+		{"couplets", []string{"Over the river", "Forever and ever", "I see the light", "Into the night"}, "AABB"},
+		{"alternating", []string{"Плывут облака", "В густой траве", "Течёт река", "Звенит в листве"}, "ABAB"},
+		{"open syllables need the consonant", []string{"Течёт река", "Растёт трава"}, "AB"},
+		{"blank lines", []string{"Into the night", "...", "I see the light"}, "A-A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Scheme(tt.lines); got != tt.want {
+				t.Errorf("Scheme(%q) = %q, want %q", tt.lines, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	sections := models.LyricSections{
+		{Type: models.SectionVerse, Label: "Verse 1", Number: 1, Lines: []string{"I see the light", "Into the night"}},
+		{Type: models.SectionChorus, Label: "Chorus", Lines: []string{"Hold on", "Hold on"}},
+		{Type: models.SectionChorus, Label: "Chorus", Lines: []string{"Hold on", "Hold on"}, Repeat: true},
+	}
+
+	got := Analyze(sections)
+	if got.Sections != 3 || got.Lines != 6 || got.Words != 15 || got.UniqueWords != 8 {
+		t.Errorf("Analyze() counts = %d sections, %d lines, %d words, %d unique; want 3, 6, 15, 8",
+			got.Sections, got.Lines, got.Words, got.UniqueWords)
+	}
+	if want := map[models.SectionType]int{models.SectionVerse: 1, models.SectionChorus: 2}; !reflect.DeepEqual(got.SectionTypes, want) {
+		t.Errorf("Analyze() section types = %v, want %v", got.SectionTypes, want)
+	}
+	if want := []models.RepeatedLine{{Text: "Hold on", Count: 4}}; !reflect.DeepEqual(got.RepeatedLines, want) {
+		t.Errorf("Analyze() repeated lines = %+v, want %+v", got.RepeatedLines, want)
+	}
+	if len(got.RhymeSchemes) != 3 || got.RhymeSchemes[0].Scheme != "AA" || got.RhymeSchemes[2].Index != 3 {
+		t.Errorf("Analyze() rhyme schemes = %+v, want one per section", got.RhymeSchemes)
+	}
+	if got.UniqueWordRatio != 0.533 || got.ReadingTimeSeconds != 4.5 {
+		t.Errorf("Analyze() ratio = %v, reading time = %v; want 0.533 and 4.5", got.UniqueWordRatio, got.ReadingTimeSeconds)
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache(1)
+	song := &models.Song{ID: 1, Text: "Hold on\nHold on"}
+	sections := models.ParseSections(song.Text)
+
+	if _, cached := cache.Get(song, sections); cached {
+		t.Error("first Get() cached = true, want false")
+	}
+	result, cached := cache.Get(song, sections)
+	if !cached || result.SongID != 1 {
+		t.Errorf("second Get() = song %d, cached %v; want song 1 from the cache", result.SongID, cached)
+	}
+
+	song.Text = "Let go"
+	if _, cached := cache.Get(song, models.ParseSections(song.Text)); cached {
+		t.Error("Get() after a text change cached = true, want false")
+	}
+
+	cache.Get(&models.Song{ID: 2, Text: "Other"}, models.ParseSections("Other"))
+	if _, cached := cache.Get(song, models.ParseSections(song.Text)); cached {
+		t.Error("Get() of an evicted song cached = true, want false")
+	}
+
+	cache.Remove(1)
+	if _, cached := cache.Get(song, models.ParseSections(song.Text)); cached {
+		t.Error("Get() after Remove() cached = true, want false")
+	}
+}
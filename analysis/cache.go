@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"go-tunes/models"
+	"hash/fnv"
+	"sync"
+)
+
+// cacheEntry — результат анализа и хеш текста, по которому он вычислен
+type cacheEntry struct {
+	textHash uint64
+	result   models.LyricsAnalysis
+}
+
+// Cache хранит результаты анализа песен, пока не изменится их текст. Потокобезопасен.
+// При переполнении вытесняется произвольная запись.
+type Cache struct {
+	mu       sync.Mutex
+	entries  map[uint]cacheEntry
+	capacity int
+}
+
+func NewCache(capacity int) *Cache {
+	return &Cache{entries: make(map[uint]cacheEntry), capacity: max(capacity, 1)}
+}
+
+// Get возвращает анализ текста песни, разобранного на части sections. Если текст песни не изменился
+// с прошлого вызова, возвращается сохранённый результат; второе значение сообщает, взят ли результат из кеша.
+func (c *Cache) Get(song *models.Song, sections models.LyricSections) (models.LyricsAnalysis, bool) {
+	textHash := hashText(song.Text)
+	c.mu.Lock()
+	entry, ok := c.entries[song.ID]
+	c.mu.Unlock()
+	if ok && entry.textHash == textHash {
+		return entry.result, true
+	}
+
+	result := Analyze(sections)
+	result.SongID = song.ID
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[song.ID]; !exists && len(c.entries) >= c.capacity {
+		for id := range c.entries {
+			delete(c.entries, id)
+			break
+		}
+	}
+	c.entries[song.ID] = cacheEntry{textHash: textHash, result: result}
+	return result, false
+}
+
+// Remove удаляет результат анализа песни
+func (c *Cache) Remove(id uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+func hashText(text string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(text))
+	return hash.Sum64()
+}
@@ -0,0 +1,271 @@
+// Package autocomplete хранит в памяти префиксный индекс названий групп и песен для подсказок при вводе.
+package autocomplete
+
+import (
+	"fmt"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"go-tunes/translit"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Field — поле песни, по которому строятся подсказки
+type Field string
+
+const (
+	FieldGroup Field = "group"
+	FieldSong  Field = "song"
+)
+
+// ParseField проверяет название поля из параметра запроса
+func ParseField(value string) (Field, error) {
+	switch Field(value) {
+	case FieldGroup, FieldSong:
+		return Field(value), nil
+	default:
+		return "", fmt.Errorf("unknown autocomplete field %q: expected group or song", value)
+	}
+}
+
+// term — одно название в индексе. Варианты написания, сводящиеся к одному ключу поиска
+// (например, "Кино" и "Kino"), объединяются; показывается самый распространённый.
+type term struct {
+	names map[string]int // Написание названия -> количество песен с ним
+	songs int            // Количество песен с этим названием
+	views int            // Количество обращений к этим песням с момента запуска
+}
+
+func (t *term) popularity() int {
+	return t.songs + t.views
+}
+
+func (t *term) display() string {
+	best, bestCount := "", 0
+	for name, count := range t.names {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// prefixEntry связывает начало слова названия с ключом названия: название "Supermassive Black Hole"
+// доступно по префиксам "supermassive black hole", "black hole" и "hole"
+type prefixEntry struct {
+	prefix string
+	key    string
+}
+
+// fieldIndex — индекс одного поля: названия по ключу и отсортированный список префиксов
+type fieldIndex struct {
+	terms    map[string]*term
+	prefixes []prefixEntry
+}
+
+// indexedSong — названия песни, под которыми она учтена в индексе
+type indexedSong struct {
+	group string
+	song  string
+}
+
+// Index — префиксный индекс названий групп и песен, ранжирующий подсказки по популярности:
+// количеству песен с названием и количеству обращений к ним
+type Index struct {
+	mu     sync.RWMutex
+	songs  map[uint]indexedSong
+	fields map[Field]*fieldIndex
+}
+
+func NewIndex() *Index {
+	return &Index{
+		songs: make(map[uint]indexedSong),
+		fields: map[Field]*fieldIndex{
+			FieldGroup: {terms: make(map[string]*term)},
+			FieldSong:  {terms: make(map[string]*term)},
+		},
+	}
+}
+
+// Load заполняет индекс всеми песнями хранилища, кроме находящихся в корзине
+func (idx *Index) Load(store repository.SongStore) error {
+	const batchSize = 100
+	query := repository.SongQuery{Page: 1, Limit: batchSize}
+	loaded := 0
+	for {
+		page, err := store.GetAllSongs(query)
+		if err != nil {
+			return err
+		}
+		for _, song := range page.Songs {
+			idx.Put(song)
+		}
+		loaded += len(page.Songs)
+		if !page.HasNext || len(page.Songs) == 0 {
+			break
+		}
+		query.Cursor = &repository.Cursor{Key: page.Songs[len(page.Songs)-1]}
+	}
+	log.Printf("INFO: Autocomplete index loaded with %d songs", loaded)
+	return nil
+}
+
+// Put добавляет песню в индекс или обновляет её названия
+func (idx *Index) Put(song models.Song) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if previous, ok := idx.songs[song.ID]; ok {
+		if previous.group == song.Group && previous.song == song.Song {
+			return
+		}
+		idx.removeLocked(song.ID, previous)
+	}
+	idx.songs[song.ID] = indexedSong{group: song.Group, song: song.Song}
+	idx.fields[FieldGroup].add(song.Group)
+	idx.fields[FieldSong].add(song.Song)
+}
+
+// Remove удаляет песню из индекса
+func (idx *Index) Remove(id uint) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if previous, ok := idx.songs[id]; ok {
+		idx.removeLocked(id, previous)
+	}
+}
+
+// Touch учитывает обращение к песне, повышая популярность её названий
+func (idx *Index) Touch(id uint) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if song, ok := idx.songs[id]; ok {
+		idx.fields[FieldGroup].touch(song.group)
+		idx.fields[FieldSong].touch(song.song)
+	}
+}
+
+// Suggest возвращает до limit названий, начинающихся с prefix (с начала названия или с любого его слова),
+// от самого популярного. Префикс сравнивается с учётом регистра, пунктуации и транслитерации.
+func (idx *Index) Suggest(field Field, prefix string, limit int) []models.AutocompleteSuggestion {
+	suggestions := make([]models.AutocompleteSuggestion, 0, limit)
+	prefix = translit.Key(prefix)
+	if prefix == "" {
+		return suggestions
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	index := idx.fields[field]
+	seen := make(map[string]bool)
+	start := sort.Search(len(index.prefixes), func(i int) bool { return index.prefixes[i].prefix >= prefix })
+	for i := start; i < len(index.prefixes) && strings.HasPrefix(index.prefixes[i].prefix, prefix); i++ {
+		key := index.prefixes[i].key
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		t := index.terms[key]
+		suggestions = append(suggestions, models.AutocompleteSuggestion{
+			Value:      t.display(),
+			Songs:      t.songs,
+			Popularity: t.popularity(),
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Popularity != suggestions[j].Popularity {
+			return suggestions[i].Popularity > suggestions[j].Popularity
+		}
+		return suggestions[i].Value < suggestions[j].Value
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func (idx *Index) removeLocked(id uint, song indexedSong) {
+	delete(idx.songs, id)
+	idx.fields[FieldGroup].remove(song.group)
+	idx.fields[FieldSong].remove(song.song)
+}
+
+func (f *fieldIndex) add(name string) {
+	key := translit.Key(name)
+	if key == "" {
+		return
+	}
+	t, ok := f.terms[key]
+	if !ok {
+		t = &term{names: make(map[string]int)}
+		f.terms[key] = t
+		for _, prefix := range wordStarts(key) {
+			f.insertPrefix(prefixEntry{prefix: prefix, key: key})
+		}
+	}
+	t.names[name]++
+	t.songs++
+}
+
+func (f *fieldIndex) remove(name string) {
+	key := translit.Key(name)
+	t, ok := f.terms[key]
+	if !ok {
+		return
+	}
+	t.songs--
+	if t.names[name]--; t.names[name] <= 0 {
+		delete(t.names, name)
+	}
+	if t.songs > 0 {
+		return
+	}
+	delete(f.terms, key)
+	for _, prefix := range wordStarts(key) {
+		f.deletePrefix(prefixEntry{prefix: prefix, key: key})
+	}
+}
+
+func (f *fieldIndex) touch(name string) {
+	if t, ok := f.terms[translit.Key(name)]; ok {
+		t.views++
+	}
+}
+
+func (f *fieldIndex) search(entry prefixEntry) int {
+	return sort.Search(len(f.prefixes), func(i int) bool {
+		current := f.prefixes[i]
+		return current.prefix > entry.prefix || (current.prefix == entry.prefix && current.key >= entry.key)
+	})
+}
+
+func (f *fieldIndex) insertPrefix(entry prefixEntry) {
+	i := f.search(entry)
+	f.prefixes = append(f.prefixes, prefixEntry{})
+	copy(f.prefixes[i+1:], f.prefixes[i:])
+	f.prefixes[i] = entry
+}
+
+func (f *fieldIndex) deletePrefix(entry prefixEntry) {
+	i := f.search(entry)
+	if i < len(f.prefixes) && f.prefixes[i] == entry {
+		f.prefixes = append(f.prefixes[:i], f.prefixes[i+1:]...)
+	}
+}
+
+// wordStarts возвращает ключ, начиная с каждого его слова: "black hole sun" -> "black hole sun", "hole sun", "sun"
+func wordStarts(key string) []string {
+	starts := []string{key}
+	for i := 0; i < len(key); i++ {
+		if key[i] == ' ' {
+			starts = append(starts, key[i+1:])
+		}
+	}
+	return starts
+}
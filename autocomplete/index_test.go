@@ -0,0 +1,103 @@
+package autocomplete
+
+import (
+	"go-tunes/models"
+	"go-tunes/repository"
+	"reflect"
+	"testing"
+)
+
+func TestParseField(t *testing.T) {
+	for _, value := range []string{"group", "song"} {
+		if got, err := ParseField(value); err != nil || string(got) != value {
+			t.Errorf("ParseField(%q) = %q, %v; want %q", value, got, err, value)
+		}
+	}
+	if _, err := ParseField("text"); err == nil {
+		t.Error(`ParseField("text") error = nil, want an error`)
+	}
+}
+
+func TestIndexSuggest(t *testing.T) {
+	idx := NewIndex()
+	idx.Put(models.Song{ID: 1, Group: "Кино", Song: "Звезда по имени Солнце"})
+	idx.Put(models.Song{ID: 2, Group: "Кино", Song: "Группа крови"})
+	idx.Put(models.Song{ID: 3, Group: "Kino", Song: "Кукушка"})
+	idx.Put(models.Song{ID: 4, Group: "Muse", Song: "Supermassive Black Hole"})
+	idx.Put(models.Song{ID: 5, Group: "Muzhskoe Delo", Song: "Black Hole Sun"})
+
+	tests := []struct {
+		name   string
+		field  Field
+		prefix string
+		limit  int
+		want   []models.AutocompleteSuggestion
+	}{
+		{"spellings are merged", FieldGroup, "kin", 10, []models.AutocompleteSuggestion{{Value: "Кино", Songs: 3, Popularity: 3}}},
+		{"transliterated prefix", FieldGroup, "Ки", 10, []models.AutocompleteSuggestion{{Value: "Кино", Songs: 3, Popularity: 3}}},
+		{"word inside the name", FieldSong, "hole", 10, []models.AutocompleteSuggestion{
+			{Value: "Black Hole Sun", Songs: 1, Popularity: 1},
+			{Value: "Supermassive Black Hole", Songs: 1, Popularity: 1},
+		}},
+		{"limit", FieldSong, "black", 1, []models.AutocompleteSuggestion{{Value: "Black Hole Sun", Songs: 1, Popularity: 1}}},
+		{"no match", FieldGroup, "queen", 10, []models.AutocompleteSuggestion{}},
+		{"blank prefix", FieldGroup, " !", 10, []models.AutocompleteSuggestion{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idx.Suggest(tt.field, tt.prefix, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q, %q) = %+v, want %+v", tt.field, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexPopularity(t *testing.T) {
+	idx := NewIndex()
+	idx.Put(models.Song{ID: 1, Group: "Muse", Song: "Uprising"})
+	idx.Put(models.Song{ID: 2, Group: "Muse", Song: "Hysteria"})
+	idx.Put(models.Song{ID: 3, Group: "Muzhskoe Delo", Song: "Mu"})
+	for i := 0; i < 3; i++ {
+		idx.Touch(3)
+	}
+
+	got := idx.Suggest(FieldGroup, "mu", 10)
+	want := []models.AutocompleteSuggestion{
+		{Value: "Muzhskoe Delo", Songs: 1, Popularity: 4},
+		{Value: "Muse", Songs: 2, Popularity: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIndexPutAndRemove(t *testing.T) {
+	idx := NewIndex()
+	idx.Put(models.Song{ID: 1, Group: "Muse", Song: "Uprisng"})
+	idx.Put(models.Song{ID: 1, Group: "Muse", Song: "Uprising"})
+	if got := idx.Suggest(FieldSong, "upr", 10); len(got) != 1 || got[0].Value != "Uprising" {
+		t.Errorf("Suggest() after rename = %+v, want only the new name", got)
+	}
+
+	idx.Remove(1)
+	if got := idx.Suggest(FieldGroup, "muse", 10); len(got) != 0 {
+		t.Errorf("Suggest() after Remove() = %+v, want none", got)
+	}
+}
+
+func TestIndexLoad(t *testing.T) {
+	store := repository.NewMemorySongRepository()
+	for _, name := range []string{"Uprising", "Hysteria", "Starlight"} {
+		if _, err := store.SaveSong(&models.Song{Group: "Muse", Song: name}); err != nil {
+			t.Fatalf("SaveSong() error = %v", err)
+		}
+	}
+
+	idx := NewIndex()
+	if err := idx.Load(store); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := idx.Suggest(FieldGroup, "muse", 10); len(got) != 1 || got[0].Songs != 3 {
+		t.Errorf("Suggest() after Load() = %+v, want Muse with 3 songs", got)
+	}
+}
@@ -0,0 +1,291 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-tunes/models"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry описывает одну запись каталога обогащения
+type Entry struct {
+	Group       string             `json:"group"`
+	Song        string             `json:"song"`
+	ReleaseDate models.ReleaseDate `json:"release_date"`
+	Text        string             `json:"text"`
+	Link        string             `json:"link"`
+	models.TrackMetadata
+}
+
+// Catalog хранит записи обогащения в памяти, проиндексированные по нормализованной паре группа+песня.
+// Источником служит JSON-файл (объект или массив), JSONL-файл или каталог с такими файлами.
+type Catalog struct {
+	path string
+
+	mu        sync.RWMutex
+	entries   map[string]Entry
+	signature uint64
+}
+
+// Load загружает каталог из файла или директории
+func Load(path string) (*Catalog, error) {
+	c := &Catalog{path: path}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Key возвращает нормализованный ключ каталога: регистр и лишние пробелы не учитываются
+func Key(group, song string) string {
+	return normalize(group) + "\x00" + normalize(song)
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// Lookup ищет запись каталога по группе и названию песни
+func (c *Catalog) Lookup(group, song string) (models.SongDetail, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[Key(group, song)]
+	c.mu.RUnlock()
+	if !ok {
+		return models.SongDetail{}, false
+	}
+	return models.SongDetail{
+		ReleaseDate:   entry.ReleaseDate,
+		Text:          entry.Text,
+		Link:          entry.Link,
+		TrackMetadata: entry.TrackMetadata,
+	}, true
+}
+
+// Len возвращает количество записей в каталоге
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Reload перечитывает источник каталога и атомарно заменяет индекс.
+// При ошибке разбора ранее загруженные данные сохраняются.
+func (c *Catalog) Reload() error {
+	files, signature, err := c.sourceFiles()
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]Entry)
+	for _, file := range files {
+		records, err := readFile(file)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.Group == "" || record.Song == "" {
+				log.Printf("WARNING: Skipping catalog record without group or song in %s", file)
+				continue
+			}
+			if err := record.TrackMetadata.Normalize(); err != nil {
+				log.Printf("WARNING: Dropping invalid track metadata of '%s' - '%s' in %s: %v", record.Group, record.Song, file, err)
+			}
+			entries[Key(record.Group, record.Song)] = record
+		}
+	}
+
+	c.mu.Lock()
+	c.entries = entries
+	c.signature = signature
+	c.mu.Unlock()
+
+	log.Printf("INFO: Enrichment catalog loaded from %s: %d records", c.path, len(entries))
+	return nil
+}
+
+// Watch периодически проверяет источник каталога и перезагружает его при изменении файлов.
+// Работает до отмены контекста.
+func (c *Catalog) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, signature, err := c.sourceFiles()
+			if err != nil {
+				log.Printf("ERROR: Could not check enrichment catalog %s: %v", c.path, err)
+				continue
+			}
+			c.mu.RLock()
+			changed := signature != c.signature
+			c.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := c.Reload(); err != nil {
+				log.Printf("ERROR: Could not reload enrichment catalog %s: %v", c.path, err)
+			}
+		}
+	}
+}
+
+// sourceFiles возвращает отсортированный список файлов каталога и подпись их состояния (имя, размер, время изменения)
+func (c *Catalog) sourceFiles() ([]string, uint64, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not open catalog: %w", err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.WalkDir(c.path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isCatalogFile(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not list catalog directory: %w", err)
+		}
+		sort.Strings(files)
+	} else {
+		files = []string{c.path}
+	}
+
+	hash := fnv.New64a()
+	for _, file := range files {
+		stat, err := os.Stat(file)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not stat catalog file: %w", err)
+		}
+		fmt.Fprintf(hash, "%s|%d|%d\n", file, stat.Size(), stat.ModTime().UnixNano())
+	}
+	return files, hash.Sum64(), nil
+}
+
+func isCatalogFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl":
+		return true
+	}
+	return false
+}
+
+// readFile разбирает JSON-объект, JSON-массив или JSONL (одна запись на строку)
+func readFile(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read catalog file %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		return readLines(path, data)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	if trimmed[0] == '[' {
+		var records []json.RawMessage
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("could not parse catalog file %s: %w", path, err)
+		}
+		entries := make([]Entry, 0, len(records))
+		for i, record := range records {
+			entry, err := decodeEntry(record, fmt.Sprintf("%s, record %d", path, i+1))
+			if err != nil {
+				return nil, fmt.Errorf("could not parse catalog file %s, record %d: %w", path, i+1, err)
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	entry, err := decodeEntry(trimmed, path)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse catalog file %s: %w", path, err)
+	}
+	return []Entry{entry}, nil
+}
+
+func readLines(path string, data []byte) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		entry, err := decodeEntry(text, fmt.Sprintf("%s, line %d", path, line))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse catalog file %s, line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read catalog file %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// decodeEntry разбирает одну запись каталога. Поля разбираются по отдельности: некорректное значение
+// (например, дата релиза или длительность не в том формате) отбрасывается с предупреждением, остальные поля
+// записи сохраняются. Ошибка возвращается, только если запись не является JSON-объектом.
+func decodeEntry(data []byte, source string) (Entry, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Entry{}, err
+	}
+
+	var entry Entry
+	var problems []string
+	for _, name := range sortedKeys(fields) {
+		field, err := json.Marshal(map[string]json.RawMessage{name: fields[name]})
+		if err != nil {
+			return Entry{}, err
+		}
+		var probe Entry
+		if err := json.Unmarshal(field, &probe); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if err := json.Unmarshal(field, &entry); err != nil {
+			return Entry{}, err
+		}
+	}
+	if len(problems) > 0 {
+		log.Printf("WARNING: Dropping invalid fields of catalog record '%s' - '%s' in %s: %s",
+			entry.Group, entry.Song, source, strings.Join(problems, "; "))
+	}
+	return entry, nil
+}
+
+func sortedKeys(fields map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
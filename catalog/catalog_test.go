@@ -0,0 +1,175 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
+
+func TestLoadFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want int
+	}{
+		{"object", "song.json", `{"group":"Muse","song":"Uprising","text":"text","link":"link"}`, 1},
+		{"array", "songs.json", `[{"group":"Muse","song":"Uprising","text":"text","link":"link"},{"group":"Muse","song":"Hysteria"}]`, 2},
+		{"lines", "songs.jsonl", "{\"group\":\"Muse\",\"song\":\"Uprising\",\"text\":\"text\",\"link\":\"link\"}\n\n{\"group\":\"Muse\",\"song\":\"Hysteria\"}\n", 2},
+		{"empty", "empty.json", "  \n", 0},
+		{"record without a song", "songs.json", `[{"group":"Muse","song":"Uprising","text":"text","link":"link"},{"group":"Muse"}]`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			writeFile(t, path, tt.data)
+
+			c, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := c.Len(); got != tt.want {
+				t.Errorf("Len() = %d, want %d", got, tt.want)
+			}
+			if tt.want == 0 {
+				return
+			}
+			detail, ok := c.Lookup("  MUSE ", "uprising")
+			if !ok || detail.Text != "text" || detail.Link != "link" {
+				t.Errorf("Lookup() = %+v, %v; want the Uprising record", detail, ok)
+			}
+		})
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.json"), `{"group":"Muse","song":"Uprising","text":"old"}`)
+	writeFile(t, filepath.Join(dir, "b.jsonl"), `{"group":"Muse","song":"Uprising","text":"new"}`)
+	writeFile(t, filepath.Join(dir, "notes.txt"), "not a catalog file")
+	if err := os.Mkdir(filepath.Join(dir, "more"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "more", "c.json"), `[{"group":"Кино","song":"Кукушка"}]`)
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if detail, _ := c.Lookup("Muse", "Uprising"); detail.Text != "new" {
+		t.Errorf("Lookup() text = %q, want the record from the later file", detail.Text)
+	}
+	if _, ok := c.Lookup("кино", "кукушка"); !ok {
+		t.Error("Lookup() of a record in a subdirectory = false, want true")
+	}
+}
+
+func TestReloadKeepsEntriesOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "songs.json")
+	writeFile(t, path, `{"group":"Muse","song":"Uprising","text":"text"}`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	writeFile(t, path, `{"group":`)
+	if err := c.Reload(); err == nil {
+		t.Fatal("Reload() error = nil, want an error for a malformed file")
+	}
+	if _, ok := c.Lookup("Muse", "Uprising"); !ok {
+		t.Error("Lookup() after a failed Reload() = false, want the previous records")
+	}
+}
+
+func TestLoadMissingPath(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() error = nil, want an error for a missing path")
+	}
+}
+
+func TestLoadDropsInvalidFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		record   string
+		wantDate string
+		wantISRC string
+	}{
+		{"invalid release date", "songs.json", `{"group":"Muse","song":"Uprising","release_date":"someday","text":"text","isrc":"GBAHT0900320"}`, "", "GBAHT0900320"},
+		{"non-string release date", "songs.json", `{"group":"Muse","song":"Uprising","release_date":2009,"text":"text","isrc":"GBAHT0900320"}`, "", "GBAHT0900320"},
+		{"invalid isrc", "songs.jsonl", `{"group":"Muse","song":"Uprising","release_date":"07.09.2009","text":"text","isrc":"not an isrc"}`, "2009-09-07", ""},
+		{"wrong metadata types", "songs.jsonl", `{"group":"Muse","song":"Uprising","release_date":"07.09.2009","text":"text","duration_seconds":"5:04","explicit":"no"}`, "2009-09-07", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			other := `{"group":"Muse","song":"Hysteria","text":"other"}`
+			if tt.file == "songs.jsonl" {
+				writeFile(t, path, tt.record+"\n"+other+"\n")
+			} else {
+				writeFile(t, path, "["+tt.record+","+other+"]")
+			}
+
+			c, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := c.Len(); got != 2 {
+				t.Errorf("Len() = %d, want both records", got)
+			}
+			detail, ok := c.Lookup("Muse", "Uprising")
+			if !ok || detail.Text != "text" {
+				t.Fatalf("Lookup() = %+v, %v; want the record with the invalid field dropped", detail, ok)
+			}
+			if got := detail.ReleaseDate.String(); got != tt.wantDate {
+				t.Errorf("release date = %q, want %q", got, tt.wantDate)
+			}
+			isrc := ""
+			if detail.ISRC != nil {
+				isrc = *detail.ISRC
+			}
+			if isrc != tt.wantISRC {
+				t.Errorf("isrc = %q, want %q", isrc, tt.wantISRC)
+			}
+			if detail.DurationSeconds != nil || detail.Explicit != nil {
+				t.Errorf("duration = %v, explicit = %v; want invalid values dropped", detail.DurationSeconds, detail.Explicit)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsValidFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "songs.jsonl")
+	writeFile(t, path, `{"group":"Muse","song":"Uprising","release_date":"2009-09","isrc":"GB-AHT-09-00320","bpm":130,"duration_seconds":"long"}`+"\n")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	detail, _ := c.Lookup("Muse", "Uprising")
+	if got := detail.ReleaseDate.String(); got != "2009-09" {
+		t.Errorf("release date = %q, want %q", got, "2009-09")
+	}
+	if detail.ISRC == nil || *detail.ISRC != "GBAHT0900320" || detail.BPM == nil || *detail.BPM != 130 {
+		t.Errorf("metadata = %+v, want the valid fields kept", detail.TrackMetadata)
+	}
+}
+
+func TestLoadRejectsMalformedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "songs.jsonl")
+	writeFile(t, path, `{"group":"Muse","song":"Uprising"}`+"\n"+`["not", "an", "object"]`+"\n")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Load() error = %v, want an error for line 2", err)
+	}
+}
@@ -0,0 +1,129 @@
+package chordpro
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Accidentals задаёт запись нот с альтерацией при транспонировании
+type Accidentals string
+
+const (
+	AccidentalsAuto  Accidentals = "auto"  // По тональности: бемоли в бемольных тональностях, иначе диезы
+	AccidentalsSharp Accidentals = "sharp" // Всегда диезы: C#, F#
+	AccidentalsFlat  Accidentals = "flat"  // Всегда бемоли: Db, Gb
+)
+
+// ParseAccidentals проверяет значение параметра запроса
+func ParseAccidentals(value string) (Accidentals, error) {
+	switch Accidentals(value) {
+	case "":
+		return AccidentalsAuto, nil
+	case AccidentalsAuto, AccidentalsSharp, AccidentalsFlat:
+		return Accidentals(value), nil
+	default:
+		return "", fmt.Errorf("unknown accidentals %q: expected auto, sharp or flat", value)
+	}
+}
+
+var (
+	sharpNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+	flatNames  = [12]string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+	naturals   = map[byte]int{'C': 0, 'D': 2, 'E': 4, 'F': 5, 'G': 7, 'A': 9, 'B': 11, 'H': 11}
+
+	// flatKeys — тональности, в которых принято писать бемоли (мажорные и минорные)
+	flatKeys = map[string]bool{
+		"F": true, "Bb": true, "Eb": true, "Ab": true, "Db": true, "Gb": true,
+		"Dm": true, "Gm": true, "Cm": true, "Fm": true, "Bbm": true, "Ebm": true,
+	}
+
+	chordPattern = regexp.MustCompile(`^([A-H])([#b♯♭]?)([^/]*)(?:/([A-H])([#b♯♭]?))?$`)
+)
+
+// Chord — аккорд: тоника, обозначение лада и добавленных ступеней (m7, sus4 и т. п.) и необязательный бас
+type Chord struct {
+	Root    int // Тоника, полутоны от C
+	Quality string
+	Bass    int // Бас, полутоны от C; -1 — без отдельного баса
+}
+
+// ParseChord разбирает обозначение аккорда вида C, F#m7, Bbsus4, D/F#
+func ParseChord(value string) (Chord, error) {
+	match := chordPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return Chord{}, fmt.Errorf("invalid chord %q", value)
+	}
+	chord := Chord{Root: note(match[1], match[2]), Quality: match[3], Bass: -1}
+	if match[4] != "" {
+		chord.Bass = note(match[4], match[5])
+	}
+	return chord, nil
+}
+
+func note(letter, accidental string) int {
+	value := naturals[letter[0]]
+	switch accidental {
+	case "#", "♯":
+		value++
+	case "b", "♭":
+		value--
+	}
+	return (value + 12) % 12
+}
+
+// Transpose сдвигает аккорд на semitones полутонов
+func (c Chord) Transpose(semitones int) Chord {
+	c.Root = shift(c.Root, semitones)
+	if c.Bass >= 0 {
+		c.Bass = shift(c.Bass, semitones)
+	}
+	return c
+}
+
+func shift(value, semitones int) int {
+	return ((value+semitones)%12 + 12) % 12
+}
+
+// Format записывает аккорд диезами или бемолями
+func (c Chord) Format(flats bool) string {
+	names := sharpNames
+	if flats {
+		names = flatNames
+	}
+	result := names[c.Root] + c.Quality
+	if c.Bass >= 0 {
+		result += "/" + names[c.Bass]
+	}
+	return result
+}
+
+// IsMinor сообщает, минорный ли аккорд (m, min, но не maj)
+func (c Chord) IsMinor() bool {
+	return (strings.HasPrefix(c.Quality, "m") && !strings.HasPrefix(c.Quality, "maj")) || strings.HasPrefix(c.Quality, "-")
+}
+
+// usesFlats определяет запись альтерации для тональности, заданной аккордом тоники
+func usesFlats(key Chord, accidentals Accidentals) bool {
+	switch accidentals {
+	case AccidentalsSharp:
+		return false
+	case AccidentalsFlat:
+		return true
+	}
+	name := flatNames[key.Root]
+	if key.IsMinor() {
+		name += "m"
+	}
+	return flatKeys[name]
+}
+
+// isAnnotation сообщает, что содержимое квадратных скобок не аккорд, а пометка ([*Coda], [N.C.])
+func isAnnotation(value string) bool {
+	value = strings.TrimSpace(value)
+	switch strings.ToUpper(value) {
+	case "N.C.", "NC", "N.C", "X", "-":
+		return true
+	}
+	return strings.HasPrefix(value, "*") || value == ""
+}
@@ -0,0 +1,473 @@
+// Package chordpro разбирает аккордовые листы в формате ChordPro, транспонирует аккорды
+// и выводит лист обычным текстом (аккорды над строками) или в HTML.
+package chordpro
+
+import (
+	"fmt"
+	"go-tunes/models"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxProblems ограничивает количество ошибок, о которых сообщает Parse
+const maxProblems = 20
+
+var directivePattern = regexp.MustCompile(`^\{\s*([A-Za-z_-]+)\s*(?:[:\s]\s*(.*?))?\s*\}$`)
+
+// LineKind — тип строки листа
+type LineKind int
+
+const (
+	LineLyrics       LineKind = iota // Строка текста с аккордами
+	LineEmpty                        // Пустая строка
+	LineComment                      // Комментарий {comment: ...}
+	LineSectionStart                 // Начало части {start_of_chorus} и т. п.
+	LineSectionEnd                   // Конец части {end_of_chorus}
+)
+
+// Segment — аккорд и текст, над началом которого он стоит. Аккорд может быть пустым (текст до первого аккорда),
+// текст — тоже (аккорд в конце строки или строка из одних аккордов).
+type Segment struct {
+	Chord string
+	Text  string
+}
+
+// Line — строка листа
+type Line struct {
+	Kind     LineKind
+	Segments []Segment // Для LineLyrics
+	Text     string    // Текст комментария или метка части
+	Section  string    // Тип части для LineSectionStart и LineSectionEnd: chorus, verse, bridge, tab
+}
+
+// Sheet — разобранный аккордовый лист
+type Sheet struct {
+	Title    string
+	Subtitle string
+	Artist   string
+	Key      string
+	Capo     int
+	Lines    []Line
+}
+
+// ValidationError содержит все найденные в документе ошибки
+type ValidationError struct {
+	Problems []models.LyricsProblem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		if problem.Line > 0 {
+			messages[i] = fmt.Sprintf("line %d: %s", problem.Line, problem.Message)
+		} else {
+			messages[i] = problem.Message
+		}
+	}
+	return "invalid ChordPro: " + strings.Join(messages, "; ")
+}
+
+// sectionDirectives сопоставляет директивы начала и конца частей (включая краткие формы) с типом части
+var sectionDirectives = map[string]struct {
+	section string
+	start   bool
+}{
+	"start_of_chorus": {"chorus", true}, "soc": {"chorus", true}, "end_of_chorus": {"chorus", false}, "eoc": {"chorus", false},
+	"start_of_verse": {"verse", true}, "sov": {"verse", true}, "end_of_verse": {"verse", false}, "eov": {"verse", false},
+	"start_of_bridge": {"bridge", true}, "sob": {"bridge", true}, "end_of_bridge": {"bridge", false}, "eob": {"bridge", false},
+	"start_of_tab": {"tab", true}, "sot": {"tab", true}, "end_of_tab": {"tab", false}, "eot": {"tab", false},
+}
+
+// Parse разбирает документ ChordPro. Поддерживаются аккорды в квадратных скобках внутри строк текста,
+// метаданные {title}, {subtitle}, {artist}, {key}, {capo}, комментарии {comment}/{c}/{ci} и части
+// {start_of_chorus}…{end_of_chorus} (а также verse, bridge, tab и краткие формы). Прочие директивы пропускаются.
+// Аккорды проверяются; пометки вида [*Coda] и [N.C.] сохраняются как есть.
+func Parse(data string) (*Sheet, error) {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	sheet := &Sheet{}
+	var problems []models.LyricsProblem
+	report := func(line int, format string, args ...interface{}) {
+		problems = append(problems, models.LyricsProblem{Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	openSection := ""
+	chords := 0
+	for number, raw := range strings.Split(strings.TrimRight(data, "\n"), "\n") {
+		number++
+		line := strings.TrimRight(raw, " \t")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			sheet.Lines = append(sheet.Lines, Line{Kind: LineEmpty})
+		case strings.HasPrefix(trimmed, "#"):
+			// Комментарий файла, в лист не выводится
+		case strings.HasPrefix(trimmed, "{"):
+			match := directivePattern.FindStringSubmatch(trimmed)
+			if match == nil {
+				report(number, "malformed directive %s", trimmed)
+				continue
+			}
+			name, value := strings.ToLower(match[1]), strings.TrimSpace(match[2])
+			if section, ok := sectionDirectives[name]; ok {
+				if section.start {
+					if openSection != "" {
+						report(number, "{%s} inside unclosed %s section", name, openSection)
+					}
+					openSection = section.section
+					sheet.Lines = append(sheet.Lines, Line{Kind: LineSectionStart, Section: section.section, Text: value})
+				} else {
+					if openSection != section.section {
+						report(number, "{%s} without matching start of %s", name, section.section)
+					}
+					openSection = ""
+					sheet.Lines = append(sheet.Lines, Line{Kind: LineSectionEnd, Section: section.section})
+				}
+				continue
+			}
+			switch name {
+			case "title", "t":
+				sheet.Title = value
+			case "subtitle", "st":
+				sheet.Subtitle = value
+			case "artist":
+				sheet.Artist = value
+			case "key":
+				if _, err := ParseChord(value); err != nil {
+					report(number, "invalid key %q", value)
+				}
+				sheet.Key = value
+			case "capo":
+				capo, err := strconv.Atoi(value)
+				if err != nil || capo < 0 || capo > 11 {
+					report(number, "invalid capo %q: expected a fret number from 0 to 11", value)
+				}
+				sheet.Capo = capo
+			case "comment", "c", "comment_italic", "ci", "comment_box", "cb", "highlight":
+				sheet.Lines = append(sheet.Lines, Line{Kind: LineComment, Text: value})
+			}
+		default:
+			segments, err := parseSegments(line)
+			if err != nil {
+				report(number, "%v", err)
+				continue
+			}
+			for _, segment := range segments {
+				if segment.Chord != "" {
+					chords++
+				}
+			}
+			sheet.Lines = append(sheet.Lines, Line{Kind: LineLyrics, Segments: segments})
+		}
+	}
+	if openSection != "" {
+		report(0, "unclosed %s section", openSection)
+	}
+	if len(problems) == 0 && chords == 0 {
+		report(0, "no chords")
+	}
+	if len(problems) > 0 {
+		if len(problems) > maxProblems {
+			problems = problems[:maxProblems]
+		}
+		return nil, &ValidationError{Problems: problems}
+	}
+	return sheet, nil
+}
+
+// parseSegments делит строку текста на аккорды и фрагменты текста под ними
+func parseSegments(line string) ([]Segment, error) {
+	var segments []Segment
+	current := Segment{}
+	column := 1 // Колонка, с которой начинается ещё не разобранная часть строки
+	for {
+		open := strings.IndexAny(line, "[]")
+		if open < 0 {
+			break
+		}
+		if line[open] == ']' {
+			return nil, fmt.Errorf("unexpected ] at column %d", column+utf8.RuneCountInString(line[:open]))
+		}
+		end := strings.IndexAny(line[open+1:], "[]")
+		if end < 0 || line[open+1+end] == '[' {
+			return nil, fmt.Errorf("unclosed [ at column %d", column+utf8.RuneCountInString(line[:open]))
+		}
+		chord := strings.TrimSpace(line[open+1 : open+1+end])
+		if !isAnnotation(chord) {
+			if _, err := ParseChord(chord); err != nil {
+				return nil, err
+			}
+		}
+		current.Text += line[:open]
+		if current.Chord != "" || current.Text != "" {
+			segments = append(segments, current)
+		}
+		current = Segment{Chord: chord}
+		column += utf8.RuneCountInString(line[:open+end+2])
+		line = line[open+end+2:]
+	}
+	current.Text += line
+	if current.Chord != "" || current.Text != "" {
+		segments = append(segments, current)
+	}
+	return segments, nil
+}
+
+// Options — параметры транспонирования
+type Options struct {
+	Semitones   int         // Сдвиг звучания в полутонах, может быть отрицательным
+	Capo        *int        // Лад каподастра, для которого выводятся аппликатуры; nil — как в документе
+	Accidentals Accidentals // Запись диезами или бемолями
+}
+
+// Transpose возвращает копию листа с переписанными аккордами. Звучание сдвигается на Semitones полутонов;
+// при смене каподастра аппликатуры дополнительно сдвигаются так, чтобы звучание не менялось.
+// Тональность {key} указывается по звучанию.
+func Transpose(sheet *Sheet, options Options) *Sheet {
+	capo := sheet.Capo
+	if options.Capo != nil {
+		capo = *options.Capo
+	}
+	shapeShift := options.Semitones + sheet.Capo - capo
+
+	result := *sheet
+	result.Capo = capo
+	result.Lines = make([]Line, len(sheet.Lines))
+
+	flats := options.Accidentals == AccidentalsFlat
+	if tonic, ok := sheet.tonic(); ok {
+		flats = usesFlats(tonic.Transpose(shapeShift), options.Accidentals)
+	}
+	if sheet.Key != "" {
+		if key, err := ParseChord(sheet.Key); err == nil {
+			soundingFlats := usesFlats(key.Transpose(options.Semitones), options.Accidentals)
+			result.Key = key.Transpose(options.Semitones).Format(soundingFlats)
+		}
+	}
+
+	for i, line := range sheet.Lines {
+		result.Lines[i] = line
+		if line.Kind != LineLyrics {
+			continue
+		}
+		segments := make([]Segment, len(line.Segments))
+		for j, segment := range line.Segments {
+			segments[j] = segment
+			if segment.Chord == "" || isAnnotation(segment.Chord) {
+				continue
+			}
+			if chord, err := ParseChord(segment.Chord); err == nil {
+				segments[j].Chord = chord.Transpose(shapeShift).Format(flats)
+			}
+		}
+		result.Lines[i].Segments = segments
+	}
+	return &result
+}
+
+// tonic возвращает тонику аппликатур: {key}, приведённый к каподастру, или первый аккорд листа
+func (s *Sheet) tonic() (Chord, bool) {
+	if key, err := ParseChord(s.Key); err == nil {
+		return key.Transpose(-s.Capo), true
+	}
+	for _, line := range s.Lines {
+		for _, segment := range line.Segments {
+			if segment.Chord == "" || isAnnotation(segment.Chord) {
+				continue
+			}
+			if chord, err := ParseChord(segment.Chord); err == nil {
+				return chord, true
+			}
+		}
+	}
+	return Chord{}, false
+}
+
+// Format записывает лист обратно в формате ChordPro
+func Format(sheet *Sheet) string {
+	var builder strings.Builder
+	for _, meta := range [][2]string{{"title", sheet.Title}, {"subtitle", sheet.Subtitle}, {"artist", sheet.Artist}, {"key", sheet.Key}} {
+		if meta[1] != "" {
+			fmt.Fprintf(&builder, "{%s: %s}\n", meta[0], meta[1])
+		}
+	}
+	if sheet.Capo > 0 {
+		fmt.Fprintf(&builder, "{capo: %d}\n", sheet.Capo)
+	}
+	for _, line := range sheet.Lines {
+		switch line.Kind {
+		case LineEmpty:
+		case LineComment:
+			builder.WriteString("{comment: " + line.Text + "}")
+		case LineSectionStart:
+			builder.WriteString("{start_of_" + line.Section)
+			if line.Text != "" {
+				builder.WriteString(": " + line.Text)
+			}
+			builder.WriteString("}")
+		case LineSectionEnd:
+			builder.WriteString("{end_of_" + line.Section + "}")
+		case LineLyrics:
+			for _, segment := range line.Segments {
+				if segment.Chord != "" {
+					builder.WriteString("[" + segment.Chord + "]")
+				}
+				builder.WriteString(segment.Text)
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// RenderText выводит лист обычным текстом: аккорды в отдельной строке над слогами, к которым они относятся.
+// Если аккорды не помещаются над текстом, текст раздвигается пробелами.
+func RenderText(sheet *Sheet) string {
+	var builder strings.Builder
+	for _, header := range sheet.headers() {
+		builder.WriteString(header + "\n")
+	}
+	if len(sheet.headers()) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, line := range sheet.Lines {
+		switch line.Kind {
+		case LineEmpty:
+			builder.WriteString("\n")
+		case LineComment:
+			builder.WriteString("(" + line.Text + ")\n")
+		case LineSectionStart:
+			builder.WriteString(sectionLabel(line) + ":\n")
+		case LineSectionEnd:
+		case LineLyrics:
+			chords, lyrics := layoutLine(line.Segments)
+			if strings.TrimSpace(chords) != "" {
+				builder.WriteString(chords + "\n")
+			}
+			if strings.TrimSpace(lyrics) != "" {
+				builder.WriteString(lyrics + "\n")
+			}
+		}
+	}
+	return builder.String()
+}
+
+// layoutLine располагает аккорды над текстом: аккорд ставится над началом своего фрагмента,
+// но не ближе чем через пробел после предыдущего аккорда
+func layoutLine(segments []Segment) (string, string) {
+	var chords, lyrics []rune
+	for _, segment := range segments {
+		if segment.Chord != "" {
+			column := len(lyrics)
+			if len(chords) > 0 && column < len(chords)+1 {
+				column = len(chords) + 1
+			}
+			for len(lyrics) < column {
+				lyrics = append(lyrics, ' ')
+			}
+			for len(chords) < column {
+				chords = append(chords, ' ')
+			}
+			chords = append(chords, []rune(segment.Chord)...)
+		}
+		lyrics = append(lyrics, []rune(segment.Text)...)
+	}
+	return strings.TrimRight(string(chords), " "), strings.TrimRight(string(lyrics), " ")
+}
+
+// RenderHTML выводит лист фрагментом HTML. Каждый аккорд с текстом под ним — отдельный блок
+// <span class="chunk">, поэтому аккорды остаются над своими слогами при любом шрифте (достаточно CSS
+// .chunk { display: inline-flex; flex-direction: column }).
+func RenderHTML(sheet *Sheet) string {
+	var builder strings.Builder
+	builder.WriteString(`<div class="chordpro">` + "\n")
+	if sheet.Title != "" {
+		builder.WriteString(`<h1 class="title">` + html.EscapeString(sheet.Title) + "</h1>\n")
+	}
+	if sheet.Subtitle != "" {
+		builder.WriteString(`<h2 class="subtitle">` + html.EscapeString(sheet.Subtitle) + "</h2>\n")
+	}
+	for _, header := range sheet.headers()[len(sheet.titles()):] {
+		builder.WriteString(`<div class="meta">` + html.EscapeString(header) + "</div>\n")
+	}
+	for _, line := range sheet.Lines {
+		switch line.Kind {
+		case LineEmpty:
+			builder.WriteString(`<div class="empty"></div>` + "\n")
+		case LineComment:
+			builder.WriteString(`<div class="comment">` + html.EscapeString(line.Text) + "</div>\n")
+		case LineSectionStart:
+			builder.WriteString(`<div class="section ` + line.Section + `">` + "\n")
+			builder.WriteString(`<div class="label">` + html.EscapeString(sectionLabel(line)) + "</div>\n")
+		case LineSectionEnd:
+			builder.WriteString("</div>\n")
+		case LineLyrics:
+			builder.WriteString(`<div class="line">`)
+			for _, segment := range line.Segments {
+				text := html.EscapeString(segment.Text)
+				if segment.Text == "" || strings.TrimSpace(segment.Text) == "" {
+					text = "&nbsp;"
+				}
+				builder.WriteString(`<span class="chunk"><span class="chord">` + html.EscapeString(segment.Chord) +
+					`</span><span class="lyric">` + text + `</span></span>`)
+			}
+			builder.WriteString("</div>\n")
+		}
+	}
+	builder.WriteString("</div>\n")
+	return builder.String()
+}
+
+// titles возвращает заголовок и подзаголовок листа
+func (s *Sheet) titles() []string {
+	var titles []string
+	for _, title := range []string{s.Title, s.Subtitle} {
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+// headers возвращает строки шапки листа: заголовки, исполнитель, тональность, каподастр
+func (s *Sheet) headers() []string {
+	headers := s.titles()
+	if s.Artist != "" {
+		headers = append(headers, "Artist: "+s.Artist)
+	}
+	if s.Key != "" {
+		headers = append(headers, "Key: "+s.Key)
+	}
+	if s.Capo > 0 {
+		headers = append(headers, fmt.Sprintf("Capo: %d", s.Capo))
+	}
+	return headers
+}
+
+func sectionLabel(line Line) string {
+	if line.Text != "" {
+		return line.Text
+	}
+	return strings.ToUpper(line.Section[:1]) + line.Section[1:]
+}
+
+// Chords возвращает аккорды листа в порядке первого появления, без повторов и пометок
+func (s *Sheet) Chords() []string {
+	chords := []string{}
+	seen := make(map[string]bool)
+	for _, line := range s.Lines {
+		for _, segment := range line.Segments {
+			if segment.Chord == "" || isAnnotation(segment.Chord) || seen[segment.Chord] {
+				continue
+			}
+			seen[segment.Chord] = true
+			chords = append(chords, segment.Chord)
+		}
+	}
+	return chords
+}
@@ -0,0 +1,209 @@
+package chordpro
+
+import (
+	"errors"
+	"go-tunes/models"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseChord(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Chord
+		wantErr bool
+	}{
+		{"C", Chord{Root: 0, Bass: -1}, false},
+		{"F#m7", Chord{Root: 6, Quality: "m7", Bass: -1}, false},
+		{"Bbsus4", Chord{Root: 10, Quality: "sus4", Bass: -1}, false},
+		{"D/F#", Chord{Root: 2, Bass: 6}, false},
+		{"Cb", Chord{Root: 11, Bass: -1}, false},
+		{"H7", Chord{Root: 11, Quality: "7", Bass: -1}, false},
+		{" E♭maj7 ", Chord{Root: 3, Quality: "maj7", Bass: -1}, false},
+		{"", Chord{}, true},
+		{"Xm", Chord{}, true},
+		{"am", Chord{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseChord(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseChord(%q) = %+v, %v; want %+v (error: %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestChordTransposeFormat(t *testing.T) {
+	tests := []struct {
+		chord     string
+		semitones int
+		flats     bool
+		want      string
+	}{
+		{"C", 2, false, "D"},
+		{"C", 1, false, "C#"},
+		{"C", 1, true, "Db"},
+		{"Am7", -2, false, "Gm7"},
+		{"D/F#", 3, true, "F/A"},
+		{"B", 13, false, "C"},
+		{"C", -13, false, "B"},
+	}
+	for _, tt := range tests {
+		chord, err := ParseChord(tt.chord)
+		if err != nil {
+			t.Fatalf("ParseChord(%q) error = %v", tt.chord, err)
+		}
+		if got := chord.Transpose(tt.semitones).Format(tt.flats); got != tt.want {
+			t.Errorf("%s transposed by %d = %q, want %q", tt.chord, tt.semitones, got, tt.want)
+		}
+	}
+}
+
+func TestChordIsMinor(t *testing.T) {
+	tests := map[string]bool{"Am": true, "Cmin7": true, "E-7": true, "Cmaj7": false, "G": false, "Dsus2": false}
+	for value, want := range tests {
+		chord, _ := ParseChord(value)
+		if got := chord.IsMinor(); got != want {
+			t.Errorf("ParseChord(%q).IsMinor() = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestParseAccidentals(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Accidentals
+		wantErr bool
+	}{
+		{"", AccidentalsAuto, false},
+		{"auto", AccidentalsAuto, false},
+		{"sharp", AccidentalsSharp, false},
+		{"flat", AccidentalsFlat, false},
+		{"natural", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAccidentals(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAccidentals(%q) = %q, %v; want %q (error: %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+const wonderwall = "\ufeff{title: Wonderwall}\r\n{artist: Oasis}\r\n{key: Em}\r\n{capo: 2}\r\n# only in the file\r\n" +
+	"{start_of_verse: Verse 1}\r\n[Em7]Today is [G]gonna be the day\r\n{end_of_verse}\r\n\r\n{c: Slowly}\r\n[Dsus4] [A7sus4] [N.C.]\r\n"
+
+func TestParse(t *testing.T) {
+	sheet, err := Parse(wonderwall)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := &Sheet{
+		Title:  "Wonderwall",
+		Artist: "Oasis",
+		Key:    "Em",
+		Capo:   2,
+		Lines: []Line{
+			{Kind: LineSectionStart, Section: "verse", Text: "Verse 1"},
+			{Kind: LineLyrics, Segments: []Segment{{Chord: "Em7", Text: "Today is "}, {Chord: "G", Text: "gonna be the day"}}},
+			{Kind: LineSectionEnd, Section: "verse"},
+			{Kind: LineEmpty},
+			{Kind: LineComment, Text: "Slowly"},
+			{Kind: LineLyrics, Segments: []Segment{{Chord: "Dsus4", Text: " "}, {Chord: "A7sus4", Text: " "}, {Chord: "N.C."}}},
+		},
+	}
+	if !reflect.DeepEqual(sheet, want) {
+		t.Errorf("Parse() = %+v, want %+v", sheet, want)
+	}
+	if got := sheet.Chords(); !slices.Equal(got, []string{"Em7", "G", "Dsus4", "A7sus4"}) {
+		t.Errorf("Chords() = %q, want the chords without annotations", got)
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []models.LyricsProblem
+	}{
+		{"no chords", "Just words", []models.LyricsProblem{{Line: 0, Message: "no chords"}}},
+		{"invalid chord", "[C]One [Q]two", []models.LyricsProblem{{Line: 1, Message: `invalid chord "Q"`}}},
+		{"unclosed bracket", "[C]One [G two", []models.LyricsProblem{{Line: 1, Message: "unclosed [ at column 8"}}},
+		{"stray bracket", "[C]One ] two", []models.LyricsProblem{{Line: 1, Message: "unexpected ] at column 8"}}},
+		{"malformed directive", "{title\n[C]One", []models.LyricsProblem{{Line: 1, Message: "malformed directive {title"}}},
+		{"invalid capo", "{capo: 12}\n[C]One", []models.LyricsProblem{{Line: 1, Message: `invalid capo "12": expected a fret number from 0 to 11`}}},
+		{"unclosed section", "{soc}\n[C]One", []models.LyricsProblem{{Line: 0, Message: "unclosed chorus section"}}},
+		{"mismatched section", "{sov}\n[C]One\n{eoc}", []models.LyricsProblem{
+			{Line: 3, Message: "{eoc} without matching start of chorus"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			var validation *ValidationError
+			if !errors.As(err, &validation) {
+				t.Fatalf("Parse() error = %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validation.Problems, tt.want) {
+				t.Errorf("Parse() problems = %+v, want %+v", validation.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	sheet, err := Parse("{key: G}\n[G]One [D/F#]two [Em]three [*Coda]")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	capo := 3
+	tests := []struct {
+		name      string
+		options   Options
+		wantKey   string
+		wantCapo  int
+		wantChord []string
+	}{
+		{"up a tone", Options{Semitones: 2}, "A", 0, []string{"A", "E/G#", "F#m", "*Coda"}},
+		{"to a flat key", Options{Semitones: 3}, "Bb", 0, []string{"Bb", "F/A", "Gm", "*Coda"}},
+		{"forced sharps", Options{Semitones: 3, Accidentals: AccidentalsSharp}, "A#", 0, []string{"A#", "F/A", "Gm", "*Coda"}},
+		{"capo keeps the sound", Options{Semitones: 3, Capo: &capo}, "Bb", 3, []string{"G", "D/F#", "Em", "*Coda"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Transpose(sheet, tt.options)
+			var chords []string
+			for _, segment := range got.Lines[0].Segments {
+				chords = append(chords, segment.Chord)
+			}
+			if got.Key != tt.wantKey || got.Capo != tt.wantCapo || !slices.Equal(chords, tt.wantChord) {
+				t.Errorf("Transpose() = key %q, capo %d, chords %q; want %q, %d, %q",
+					got.Key, got.Capo, chords, tt.wantKey, tt.wantCapo, tt.wantChord)
+			}
+		})
+	}
+	if sheet.Lines[0].Segments[0].Chord != "G" {
+		t.Error("Transpose() changed the original sheet")
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	data := "{title: Wonderwall}\n{key: Em}\n{capo: 2}\n{start_of_chorus: Chorus}\n[C]Because [D]maybe\n{end_of_chorus}\n\n{comment: Slowly}\n"
+	sheet, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := Format(sheet); got != data {
+		t.Errorf("Format(Parse()) = %q, want %q", got, data)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	sheet, err := Parse("{title: Song}\n[C]Hello [G]world\n[Am]A[F]b")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := "Song\n\nC     G\nHello world\nAm F\nA  b\n"
+	if got := RenderText(sheet); got != want {
+		t.Errorf("RenderText() = %q, want %q", got, want)
+	}
+}
@@ -1,15 +1,24 @@
 package main
 
 import (
+    "context"
     "log"
     "github.com/gin-gonic/gin"
+    "go-tunes/analysis"
+    "go-tunes/autocomplete"
+    "go-tunes/catalog"
     "go-tunes/config"
     "go-tunes/controllers"
     "go-tunes/database"
+    "go-tunes/enrichment"
+    "go-tunes/fuzzy"
+    "go-tunes/repository"
     _ "go-tunes/docs"
     "github.com/swaggo/gin-swagger"
     "github.com/swaggo/files"
     "net/http"
+    "os"
+    "time"
 )
 
 // @title Music Library API
@@ -23,36 +32,148 @@ func main() {
     config.LoadEnv()
     log.Println("INFO: Environment variables loaded.")
 
-    // Подключение к базе данных и выполнение миграций
-    db := database.Connect()
-    log.Println("INFO: Database connection established.")
-    database.Migrate(db)
-    log.Println("INFO: Database migrations completed.")
+    // Подкоманда управления миграциями: go-tunes migrate up|down|status|force
+    if len(os.Args) > 1 && os.Args[1] == "migrate" {
+        os.Exit(runMigrateCommand(os.Args[2:]))
+    }
+
+    // Загрузка каталога обогащения и отслеживание изменений его файлов
+    enrichmentCatalog, err := catalog.Load(config.GetEnv("ENRICHMENT_CATALOG_PATH", "song_enrichment.json"))
+    if err != nil {
+        log.Fatalf("ERROR: Failed to load enrichment catalog: %v", err)
+    }
+    go enrichmentCatalog.Watch(context.Background(), config.GetDuration("ENRICHMENT_CATALOG_RELOAD_INTERVAL", 5*time.Second))
+
+    // Клиент внешнего API обогащения
+    // Одновременные запросы для одной и той же песни объединяются в один запрос к внешнему API
+    enricher := enrichment.NewCoalescingEnricher(enrichment.NewClient(enrichment.Config{
+        BaseURL:          config.GetEnv("ENRICHMENT_API_URL", "http://localhost:8081"),
+        Timeout:          config.GetDuration("ENRICHMENT_API_TIMEOUT", 3*time.Second),
+        MaxRetries:       config.GetInt("ENRICHMENT_API_MAX_RETRIES", 3),
+        BackoffBase:      config.GetDuration("ENRICHMENT_API_BACKOFF", 200*time.Millisecond),
+        BackoffMax:       config.GetDuration("ENRICHMENT_API_BACKOFF_MAX", 2*time.Second),
+        BreakerThreshold: config.GetInt("ENRICHMENT_BREAKER_THRESHOLD", 5),
+        BreakerCooldown:  config.GetDuration("ENRICHMENT_BREAKER_COOLDOWN", 30*time.Second),
+    }))
+
+    // Пороги нечёткого сопоставления названий групп и песен: при каком сходстве запрос разрешается
+    // в существующую песню и какие песни предлагаются в подсказках при 404
+    matcher := fuzzy.Matcher{
+        MatchThreshold:   config.GetFloat("FUZZY_MATCH_THRESHOLD", fuzzy.DefaultMatcher.MatchThreshold),
+        SuggestThreshold: config.GetFloat("FUZZY_SUGGEST_THRESHOLD", fuzzy.DefaultMatcher.SuggestThreshold),
+        MaxSuggestions:   config.GetInt("FUZZY_MAX_SUGGESTIONS", fuzzy.DefaultMatcher.MaxSuggestions),
+    }
+
+    // Выбор хранилища: PostgreSQL (по умолчанию) или память процесса
+    store := newStore()
+
+    // Индекс подсказок строится по всем песням хранилища и далее обновляется обработчиками
+    autocompleteIndex := autocomplete.NewIndex()
+    if err := autocompleteIndex.Load(store); err != nil {
+        log.Fatal("Failed to build autocomplete index: ", err)
+    }
+
+    analysisCache := analysis.NewCache(config.GetInt("ANALYSIS_CACHE_SIZE", 1000))
+
+    // Каждый контроллер получает только нужные ему хранилища; все они реализованы одним хранилищем store
+    songController := controllers.NewSongController(store, store, store, store, enrichmentCatalog, enricher, matcher, autocompleteIndex, analysisCache)
+    artistController := controllers.NewArtistController(store, store, store, autocompleteIndex)
+    albumController := controllers.NewAlbumController(store)
+    taxonomyController := controllers.NewTaxonomyController(store, store)
 
     // Основной сервер на порту 8080
     router := gin.Default()
 
     // Определение маршрутов для основного API
-    router.GET("/info", controllers.GetSongInfo)       // Информация о песне
-    router.GET("/songs", controllers.GetSongs)         // Список песен
-    router.GET("/songs/:id/verses", controllers.GetSongTextWithPagination)  // Текст песни по ID
-    router.PUT("/songs/:id", controllers.UpdateSong)   // Обновление песни по ID
-    router.DELETE("/songs/:id", controllers.DeleteSong) // Удаление песни по ID
+    router.GET("/info", songController.GetSongInfo)       // Информация о песне
+    router.GET("/songs", songController.GetSongs)         // Список песен
+    router.GET("/search", songController.SearchSongs)     // Полнотекстовый поиск по текстам песен
+    router.GET("/autocomplete", songController.GetAutocomplete) // Подсказки названий групп и песен
+    router.POST("/songs", songController.CreateSong)      // Добавление новой песни
+    router.GET("/songs/:id/verses", songController.GetSongTextWithPagination)  // Текст песни по ID
+    router.GET("/songs/:id/analysis", songController.GetSongAnalysis)  // Статистика текста песни
+    router.PUT("/songs/:id/lyrics/lrc", songController.ImportLRC)      // Загрузка текста с синхронизацией (LRC)
+    router.GET("/songs/:id/lyrics/lrc", songController.ExportLRC)      // Выгрузка текста с синхронизацией (LRC)
+    router.DELETE("/songs/:id/lyrics/lrc", songController.DeleteLRC)   // Удаление текста с синхронизацией
+    router.GET("/songs/:id/lyrics/at", songController.GetLyricsAt)     // Строки для позиции воспроизведения
+    router.PUT("/songs/:id/chords", songController.ImportChordPro)     // Загрузка аккордового листа (ChordPro)
+    router.GET("/songs/:id/chords", songController.GetChordSheet)      // Аккорды над текстом с транспонированием
+    router.DELETE("/songs/:id/chords", songController.DeleteChordPro)  // Удаление аккордового листа
+    router.GET("/songs/:id/translations", songController.GetTranslations)            // Переводы песни
+    router.GET("/songs/:id/translations/:lang", songController.GetTranslation)       // Перевод на язык
+    router.PUT("/songs/:id/translations/:lang", songController.PutTranslation)       // Добавление или замена перевода
+    router.DELETE("/songs/:id/translations/:lang", songController.DeleteTranslation) // Удаление перевода
+    router.GET("/songs/:id/credits", songController.GetSongCredits)  // Авторы, продюсеры и приглашённые исполнители песни
+    router.POST("/songs/:id/credits", songController.AddSongCredit)  // Добавление участника песни
+    router.DELETE("/songs/:id/credits/:artist_id/:role", songController.RemoveSongCredit) // Удаление участника песни
+    router.GET("/songs/:id/genres", taxonomyController.GetSongGenres)    // Жанры песни
+    router.PUT("/songs/:id/genres", taxonomyController.SetSongGenres)    // Замена жанров песни
+    router.GET("/songs/:id/tags", taxonomyController.GetSongTags)        // Теги песни
+    router.POST("/songs/:id/tags", taxonomyController.AddSongTags)       // Добавление тегов песни
+    router.DELETE("/songs/:id/tags/:tag", taxonomyController.RemoveSongTag) // Удаление тега песни
+    router.PUT("/songs/:id", songController.UpdateSong)   // Обновление песни по ID
+    router.DELETE("/songs/:id", songController.DeleteSong) // Удаление песни по ID (в корзину или окончательно с purge=true)
+    router.GET("/songs/trash", songController.GetTrash)   // Корзина удалённых песен
+    router.POST("/songs/:id/restore", songController.RestoreSong) // Восстановление песни из корзины
+    router.POST("/artists", artistController.CreateArtist)          // Добавление исполнителя
+    router.GET("/artists", artistController.GetArtists)             // Список исполнителей
+    router.GET("/artists/:id", artistController.GetArtist)          // Исполнитель по ID
+    router.PUT("/artists/:id", artistController.UpdateArtist)       // Обновление исполнителя (с переименованием его песен)
+    router.DELETE("/artists/:id", artistController.DeleteArtist)    // Удаление исполнителя без песен
+    router.GET("/artists/:id/songs", artistController.GetArtistSongs) // Песни исполнителя
+    router.POST("/albums", albumController.CreateAlbum)            // Добавление альбома со списком композиций
+    router.GET("/albums", albumController.GetAlbums)               // Список альбомов
+    router.GET("/albums/:id", albumController.GetAlbum)            // Альбом со списком композиций
+    router.PUT("/albums/:id/tracks", albumController.SetAlbumTracks) // Новый порядок композиций альбома
+    router.DELETE("/albums/:id", albumController.DeleteAlbum)      // Удаление альбома (песни сохраняются)
+    router.POST("/genres", taxonomyController.CreateGenre)            // Добавление жанра
+    router.GET("/genres", taxonomyController.GetGenres)               // Дерево жанров
+    router.PUT("/genres/:id", taxonomyController.UpdateGenre)         // Переименование или перенос жанра
+    router.DELETE("/genres/:id", taxonomyController.DeleteGenre)      // Удаление жанра без поджанров
+    router.GET("/tags", taxonomyController.GetTagCounts)              // Количество песен по тегам
 
     // Swagger для документации
     router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
     log.Println("INFO: Swagger documentation is available at http://localhost:8080/swagger/index.html")
 
     // Второй сервер на порту 8081 - Эмуляция внешнего API
-    go startMockServer()
+    go startMockServer(enrichmentCatalog)
 
     // Запускаем основной сервер на порту 8080
     log.Println("INFO: Starting the main server on port 8080...")
     log.Fatal(router.Run(":8080"))
 }
 
+// newStore создаёт хранилище согласно переменной окружения SONG_STORAGE
+func newStore() repository.Store {
+    if os.Getenv("SONG_STORAGE") == "memory" {
+        log.Println("INFO: Using in-memory song storage.")
+        return repository.NewMemorySongRepository()
+    }
+
+    // Подключение к базе данных и применение новых миграций (можно отключить через MIGRATE_ON_START=false)
+    db := database.Connect()
+    log.Println("INFO: Database connection established.")
+    if config.GetEnv("MIGRATE_ON_START", "true") == "true" {
+        database.Migrate(db)
+        log.Println("INFO: Database migrations completed.")
+    }
+
+    // Заполнение ключей поиска и частей песни для песен, добавленных до появления этих колонок
+    songRepository := repository.NewSongRepository(db)
+    if err := songRepository.BackfillDerivedFields(); err != nil {
+        log.Printf("WARNING: Failed to backfill song derived fields: %v", err)
+    }
+    // Завершение переноса названий групп в исполнители и связывание песен без исполнителя.
+    // Переименование групп песен в каноническое название исполнителя включается ARTIST_RENAME_GROUPS=true.
+    if err := songRepository.BackfillArtists(config.GetEnv("ARTIST_RENAME_GROUPS", "false") == "true"); err != nil {
+        log.Printf("WARNING: Failed to backfill artists: %v", err)
+    }
+    return songRepository
+}
+
 // startMockServer запускает тестовый сервер на порту 8081 для эмуляции внешнего API
-func startMockServer() {
+func startMockServer(enrichmentCatalog *catalog.Catalog) {
     testRouter := gin.Default()
 
     // Определяем маршрут для эмуляции внешнего API
@@ -67,10 +188,10 @@ func startMockServer() {
             return
         }
 
-        // Используем отдельную функцию для получения данных из JSON без необходимости использования *gin.Context
-        songDetail, err := controllers.GetSongDetailFromJSON(group, song)
-        if err != nil {
-            log.Printf("DEBUG: Error fetching song details: %v\n", err)
+        // Ищем песню в каталоге обогащения
+        songDetail, ok := enrichmentCatalog.Lookup(group, song)
+        if !ok {
+            log.Printf("DEBUG: Song not found in enrichment catalog: group: %s, song: %s\n", group, song)
             c.JSON(http.StatusNotFound, gin.H{"error": "song not found"})
             return
         }
@@ -0,0 +1,114 @@
+package main
+
+import (
+    "fmt"
+    "go-tunes/database"
+    "log"
+    "os"
+    "strconv"
+    "text/tabwriter"
+)
+
+const migrateUsage = `usage: go-tunes migrate <command>
+
+commands:
+  up                применить все новые миграции
+  down [version]    откатить миграции до версии version (по умолчанию — откатить последнюю)
+  status            показать состояние миграций
+  force <version>   отметить схему как находящуюся в версии version и снять признак dirty`
+
+// runMigrateCommand выполняет подкоманду migrate и возвращает код завершения процесса
+func runMigrateCommand(args []string) int {
+    if len(args) == 0 {
+        fmt.Fprintln(os.Stderr, migrateUsage)
+        return 2
+    }
+
+    migrator, err := database.NewMigrator(database.Connect())
+    if err != nil {
+        log.Printf("ERROR: Failed to load migrations: %v", err)
+        return 1
+    }
+
+    switch args[0] {
+    case "up":
+        err = migrator.Up()
+    case "down":
+        var target uint
+        target, err = downTarget(migrator, args[1:])
+        if err == nil {
+            err = migrator.Down(target)
+        }
+    case "status":
+        err = printMigrationStatus(migrator)
+    case "force":
+        if len(args) < 2 {
+            fmt.Fprintln(os.Stderr, migrateUsage)
+            return 2
+        }
+        var version uint64
+        version, err = strconv.ParseUint(args[1], 10, 0)
+        if err == nil {
+            err = migrator.Force(uint(version))
+        }
+    default:
+        fmt.Fprintln(os.Stderr, migrateUsage)
+        return 2
+    }
+
+    if err != nil {
+        log.Printf("ERROR: migrate %s: %v", args[0], err)
+        return 1
+    }
+    return 0
+}
+
+// downTarget возвращает версию, до которой нужно откатить схему
+func downTarget(migrator *database.Migrator, args []string) (uint, error) {
+    if len(args) > 0 {
+        version, err := strconv.ParseUint(args[0], 10, 0)
+        if err != nil {
+            return 0, fmt.Errorf("invalid target version %q", args[0])
+        }
+        return uint(version), nil
+    }
+
+    // Без аргумента откатывается только последняя применённая миграция
+    statuses, err := migrator.Status()
+    if err != nil {
+        return 0, err
+    }
+    var current, previous uint
+    for _, status := range statuses {
+        if status.Applied {
+            previous, current = current, status.Version
+        }
+    }
+    if current == 0 {
+        return 0, nil
+    }
+    return previous, nil
+}
+
+func printMigrationStatus(migrator *database.Migrator) error {
+    statuses, err := migrator.Status()
+    if err != nil {
+        return err
+    }
+
+    writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(writer, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+    for _, status := range statuses {
+        state, appliedAt := "pending", ""
+        if status.Dirty {
+            state = "dirty"
+        } else if status.Applied {
+            state = "applied"
+        }
+        if status.AppliedAt != nil {
+            appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+        }
+        fmt.Fprintf(writer, "%06d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+    }
+    return writer.Flush()
+}
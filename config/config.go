@@ -3,6 +3,9 @@ package config
 import (
     "github.com/joho/godotenv"
     "log"
+    "os"
+    "strconv"
+    "time"
 )
 
 func LoadEnv() {
@@ -11,3 +14,53 @@ func LoadEnv() {
         log.Fatal("Ошибка при загрузке .env файла")
     }
 }
+
+// GetEnv возвращает значение переменной окружения или значение по умолчанию
+func GetEnv(key, fallback string) string {
+    if value, ok := os.LookupEnv(key); ok && value != "" {
+        return value
+    }
+    return fallback
+}
+
+// GetDuration возвращает длительность из переменной окружения (например, "5s") или значение по умолчанию
+func GetDuration(key string, fallback time.Duration) time.Duration {
+    value, ok := os.LookupEnv(key)
+    if !ok || value == "" {
+        return fallback
+    }
+    duration, err := time.ParseDuration(value)
+    if err != nil {
+        log.Printf("WARNING: Invalid duration in %s=%q, using default %s", key, value, fallback)
+        return fallback
+    }
+    return duration
+}
+
+// GetInt возвращает целое число из переменной окружения или значение по умолчанию
+func GetInt(key string, fallback int) int {
+    value, ok := os.LookupEnv(key)
+    if !ok || value == "" {
+        return fallback
+    }
+    number, err := strconv.Atoi(value)
+    if err != nil {
+        log.Printf("WARNING: Invalid integer in %s=%q, using default %d", key, value, fallback)
+        return fallback
+    }
+    return number
+}
+
+// GetFloat возвращает число с плавающей точкой из переменной окружения или значение по умолчанию
+func GetFloat(key string, fallback float64) float64 {
+    value, ok := os.LookupEnv(key)
+    if !ok || value == "" {
+        return fallback
+    }
+    number, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        log.Printf("WARNING: Invalid number in %s=%q, using default %g", key, value, fallback)
+        return fallback
+    }
+    return number
+}
@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlbumController обрабатывает HTTP-запросы, связанные с альбомами
+type AlbumController struct {
+	Store repository.AlbumStore
+}
+
+func NewAlbumController(store repository.AlbumStore) *AlbumController {
+	return &AlbumController{Store: store}
+}
+
+// SongAlbumFinder возвращает альбомы, на которых вышли песни
+type SongAlbumFinder interface {
+	GetSongAlbums(songIDs []uint) (map[uint][]models.SongAlbum, error)
+}
+
+// CreateAlbum adds a new album
+// @Summary Add a new album
+// @Description Add an album, EP, single or compilation with an ordered track listing of existing songs. Every type except compilation requires an artist.
+// @Accept json
+// @Produce json
+// @Param album body models.AlbumRequest true "Album"
+// @Success 201 {object} models.Album
+// @Failure 400 {string} string "invalid input, unknown artist or song"
+// @Failure 500 {string} string "internal server error"
+// @Router /albums [post]
+func (alc *AlbumController) CreateAlbum(c *gin.Context) {
+	var request models.AlbumRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("ERROR: Invalid album data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+	if request.ArtistID == nil && request.Type != models.AlbumTypeCompilation {
+		log.Printf("ERROR: Album '%s' of type %s has no artist", request.Title, request.Type)
+		c.String(http.StatusBadRequest, "artist_id is required unless type is compilation")
+		return
+	}
+	if !checkTrackList(c, request.SongIDs) {
+		return
+	}
+
+	album, err := alc.Store.CreateAlbum(&models.Album{
+		Title:       request.Title,
+		ArtistID:    request.ArtistID,
+		ReleaseDate: request.ReleaseDate,
+		Type:        request.Type,
+	}, request.SongIDs)
+	if errors.Is(err, repository.ErrArtistNotFound) {
+		log.Printf("ERROR: Artist with ID %d not found", *request.ArtistID)
+		c.String(http.StatusBadRequest, "artist not found")
+		return
+	}
+	if errors.Is(err, repository.ErrSongNotFound) {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to create album: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Created album with ID %d", album.ID)
+	c.JSON(http.StatusCreated, album)
+}
+
+// GetAlbums retrieves albums with filtering and pagination
+// @Summary Get all albums
+// @Description Retrieve albums without track listings, newest releases first
+// @Produce json
+// @Param title query string false "Part of the album title"
+// @Param artist_id query int false "Artist ID"
+// @Param type query string false "Release type" Enums(album, ep, single, compilation)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Results per page (at most 100)" default(10)
+// @Success 200 {object} models.AlbumList
+// @Failure 400 {string} string "invalid filter"
+// @Failure 500 {string} string "internal server error"
+// @Router /albums [get]
+func (alc *AlbumController) GetAlbums(c *gin.Context) {
+	query := repository.AlbumQuery{Title: c.Query("title"), Type: models.AlbumType(c.Query("type"))}
+	switch query.Type {
+	case "", models.AlbumTypeAlbum, models.AlbumTypeEP, models.AlbumTypeSingle, models.AlbumTypeCompilation:
+	default:
+		c.String(http.StatusBadRequest, "invalid type: expected album, ep, single or compilation")
+		return
+	}
+	if value := c.Query("artist_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			c.String(http.StatusBadRequest, "invalid artist_id")
+			return
+		}
+		query.ArtistID = uint(id)
+	}
+	query.Page, query.Limit = parsePagination(c, 10)
+
+	albums, total, err := alc.Store.GetAlbums(query)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve albums: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, models.AlbumList{
+		Albums:     albums,
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+	})
+}
+
+// GetAlbum retrieves an album with its track listing
+// @Summary Get an album by ID
+// @Description Retrieve an album with its tracks in order. Songs in the trash are left out of the listing.
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} models.Album
+// @Failure 400 {string} string "invalid album id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /albums/{id} [get]
+func (alc *AlbumController) GetAlbum(c *gin.Context) {
+	id, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+	album, err := alc.Store.GetAlbumByID(id)
+	if errors.Is(err, repository.ErrAlbumNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve album with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, album)
+}
+
+// SetAlbumTracks reorders the tracks of an album
+// @Summary Reorder album tracks
+// @Description Replace the track listing of an album with the given songs in order. Tracks are numbered from 1; songs missing from the list are removed from the album.
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param tracks body models.TracksRequest true "Song IDs in track order"
+// @Success 200 {object} models.Album
+// @Failure 400 {string} string "invalid input or unknown song"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /albums/{id}/tracks [put]
+func (alc *AlbumController) SetAlbumTracks(c *gin.Context) {
+	id, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+	var request models.TracksRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("ERROR: Invalid track list: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+	if !checkTrackList(c, request.SongIDs) {
+		return
+	}
+
+	album, err := alc.Store.SetAlbumTracks(id, request.SongIDs)
+	if errors.Is(err, repository.ErrAlbumNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if errors.Is(err, repository.ErrSongNotFound) {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to set tracks of album with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Set %d tracks of album with ID %d", len(album.Tracks), id)
+	c.JSON(http.StatusOK, album)
+}
+
+// DeleteAlbum deletes an album
+// @Summary Delete an album
+// @Description Delete an album and its track listing; the songs are kept
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {string} string "invalid album id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /albums/{id} [delete]
+func (alc *AlbumController) DeleteAlbum(c *gin.Context) {
+	id, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+	err := alc.Store.DeleteAlbum(id)
+	if errors.Is(err, repository.ErrAlbumNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to delete album with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted album with ID %d", id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "deleted"})
+}
+
+// parseAlbumID извлекает идентификатор альбома из пути запроса
+func parseAlbumID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Printf("ERROR: Invalid album ID %s", c.Param("id"))
+		c.String(http.StatusBadRequest, "invalid album id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// checkTrackList проверяет, что песня входит в список композиций не больше одного раза
+func checkTrackList(c *gin.Context, songIDs []uint) bool {
+	seen := make(map[uint]bool, len(songIDs))
+	for _, id := range songIDs {
+		if seen[id] {
+			log.Printf("ERROR: Song ID %d is listed twice", id)
+			c.String(http.StatusBadRequest, fmt.Sprintf("song ID %d is listed more than once", id))
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// attachAlbums дополняет песни списком альбомов, на которых они вышли
+func attachAlbums(albums SongAlbumFinder, songs []models.Song) error {
+	ids := make([]uint, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+	found, err := albums.GetSongAlbums(ids)
+	if err != nil {
+		return err
+	}
+	for i := range songs {
+		songs[i].Albums = found[songs[i].ID]
+	}
+	return nil
+}
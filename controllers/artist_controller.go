@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/autocomplete"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// minFormedYear — самый ранний допустимый год основания исполнителя
+const minFormedYear = 1000
+
+// ArtistController обрабатывает HTTP-запросы, связанные с исполнителями
+type ArtistController struct {
+	Store  repository.ArtistStore
+	Songs  SongLister
+	Albums SongAlbumFinder
+	// Autocomplete — индекс подсказок; при переименовании исполнителя обновляются подсказки его песен
+	Autocomplete *autocomplete.Index
+}
+
+func NewArtistController(store repository.ArtistStore, songs SongLister, albums SongAlbumFinder, index *autocomplete.Index) *ArtistController {
+	return &ArtistController{Store: store, Songs: songs, Albums: albums, Autocomplete: index}
+}
+
+// SongLister возвращает страницы списка песен
+type SongLister interface {
+	GetAllSongs(query repository.SongQuery) (repository.SongPage, error)
+}
+
+// CreateArtist adds a new artist
+// @Summary Add a new artist
+// @Description Add an artist with a canonical name, aliases, country (ISO 3166-1 alpha-2) and formation year. Names are compared ignoring case, punctuation and transliteration; a name that matches an existing artist's name or alias is rejected.
+// @Accept json
+// @Produce json
+// @Param artist body models.ArtistRequest true "Artist"
+// @Success 201 {object} models.Artist
+// @Failure 400 {string} string "invalid input"
+// @Failure 409 {string} string "artist already exists"
+// @Failure 500 {string} string "internal server error"
+// @Router /artists [post]
+func (arc *ArtistController) CreateArtist(c *gin.Context) {
+	artist, ok := bindArtist(c)
+	if !ok {
+		return
+	}
+	created, err := arc.Store.CreateArtist(artist)
+	if errors.Is(err, repository.ErrArtistExists) {
+		log.Printf("WARNING: Artist '%s' already exists", artist.Name)
+		c.String(http.StatusConflict, "artist already exists")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to create artist: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Created artist with ID %d", created.ID)
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetArtists retrieves artists with filtering and pagination
+// @Summary Get all artists
+// @Description Retrieve artists ordered by name. The name filter matches names and aliases ignoring case and transliteration.
+// @Produce json
+// @Param name query string false "Part of the artist name or alias"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Results per page (at most 100)" default(10)
+// @Success 200 {object} models.ArtistList
+// @Failure 500 {string} string "internal server error"
+// @Router /artists [get]
+func (arc *ArtistController) GetArtists(c *gin.Context) {
+	page, limit := parsePagination(c, 10)
+	artists, total, err := arc.Store.GetArtists(repository.ArtistQuery{Name: c.Query("name"), Page: page, Limit: limit})
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve artists: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, models.ArtistList{
+		Artists:    artists,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// GetArtist retrieves an artist by ID
+// @Summary Get an artist by ID
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Success 200 {object} models.Artist
+// @Failure 400 {string} string "invalid artist id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /artists/{id} [get]
+func (arc *ArtistController) GetArtist(c *gin.Context) {
+	id, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+	artist, ok := arc.findArtist(c, id)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, artist)
+}
+
+// UpdateArtist updates an artist
+// @Summary Update an artist
+// @Description Update an artist by its ID. Renaming the artist renames the group of all its songs.
+// @Accept json
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Param artist body models.ArtistRequest true "Updated artist data"
+// @Success 200 {object} models.Artist
+// @Failure 400 {string} string "invalid input"
+// @Failure 404 {string} string "not found"
+// @Failure 409 {string} string "artist or song already exists"
+// @Failure 500 {string} string "internal server error"
+// @Router /artists/{id} [put]
+func (arc *ArtistController) UpdateArtist(c *gin.Context) {
+	id, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+	existing, ok := arc.findArtist(c, id)
+	if !ok {
+		return
+	}
+	artist, ok := bindArtist(c)
+	if !ok {
+		return
+	}
+	artist.ID = id
+	artist.CreatedAt = existing.CreatedAt
+
+	updated, err := arc.Store.UpdateArtist(artist)
+	if errors.Is(err, repository.ErrArtistExists) {
+		log.Printf("WARNING: Artist '%s' already exists", artist.Name)
+		c.String(http.StatusConflict, "artist already exists")
+		return
+	}
+	if errors.Is(err, repository.ErrSongExists) {
+		log.Printf("WARNING: Renaming artist ID %d to '%s' conflicts with existing songs", id, artist.Name)
+		c.String(http.StatusConflict, "song already exists")
+		return
+	}
+	if errors.Is(err, repository.ErrArtistNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to update artist with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if updated.Name != existing.Name {
+		arc.refreshArtistSongs(id)
+	}
+	log.Printf("INFO: Updated artist with ID %d", id)
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteArtist deletes an artist without songs or albums
+// @Summary Delete an artist
+// @Description Delete an artist by its ID. Artists with albums or songs, including songs in the trash, cannot be deleted.
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {string} string "invalid artist id"
+// @Failure 404 {string} string "not found"
+// @Failure 409 {string} string "artist has songs or albums"
+// @Failure 500 {string} string "internal server error"
+// @Router /artists/{id} [delete]
+func (arc *ArtistController) DeleteArtist(c *gin.Context) {
+	id, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+	err := arc.Store.DeleteArtist(id)
+	if errors.Is(err, repository.ErrArtistNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if errors.Is(err, repository.ErrArtistHasSongs) || errors.Is(err, repository.ErrArtistHasAlbums) {
+		log.Printf("WARNING: Artist with ID %d cannot be deleted: %v", id, err)
+		c.String(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to delete artist with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted artist with ID %d", id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "deleted"})
+}
+
+// GetArtistSongs retrieves the songs of an artist
+// @Summary Get songs of an artist
+// @Description Retrieve the artist's songs with sorting and pagination
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Param sort query string false "Comma-separated sort fields, prefix with - for descending (id, group, song, release_date, created_at, updated_at)" example(-release_date)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Results per page (at most 100)" default(10)
+// @Success 200 {object} models.SongList
+// @Header 200 {integer} X-Total-Count "Total number of the artist's songs"
+// @Failure 400 {string} string "invalid artist id or sort"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /artists/{id}/songs [get]
+func (arc *ArtistController) GetArtistSongs(c *gin.Context) {
+	id, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+	sortFields, err := repository.ParseSort(c.Query("sort"))
+	if err != nil {
+		log.Printf("ERROR: Invalid sort parameter: %v", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := arc.findArtist(c, id); !ok {
+		return
+	}
+
+	page, limit := parsePagination(c, 10)
+	result, err := arc.Songs.GetAllSongs(repository.SongQuery{
+		Filter: repository.SongFilter{ArtistID: id},
+		Sort:   sortFields,
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve songs of artist ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if err := attachAlbums(arc.Albums, result.Songs); err != nil {
+		log.Printf("ERROR: Failed to retrieve albums of songs: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Header("X-Total-Count", strconv.FormatInt(result.Total, 10))
+	c.JSON(http.StatusOK, models.SongList{
+		Songs:      result.Songs,
+		Total:      result.Total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((result.Total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// bindArtist разбирает и проверяет тело запроса с данными исполнителя; код страны приводится к верхнему регистру
+func bindArtist(c *gin.Context) (*models.Artist, bool) {
+	var request models.ArtistRequest
+	if err := c.ShouldBindJSON(&request); err != nil || strings.TrimSpace(request.Name) == "" {
+		log.Printf("ERROR: Invalid artist data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return nil, false
+	}
+	artist := &models.Artist{Name: strings.TrimSpace(request.Name), FormedYear: request.FormedYear}
+	for _, alias := range request.Aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			artist.Aliases = append(artist.Aliases, alias)
+		}
+	}
+	if request.Country != "" {
+		region, err := language.ParseRegion(request.Country)
+		if err != nil || !region.IsCountry() || len(request.Country) != 2 {
+			log.Printf("ERROR: Invalid country code %q", request.Country)
+			c.String(http.StatusBadRequest, "invalid country: expected an ISO 3166-1 alpha-2 code")
+			return nil, false
+		}
+		artist.Country = region.String()
+	}
+	if year := request.FormedYear; year != nil && (*year < minFormedYear || *year > time.Now().Year()) {
+		log.Printf("ERROR: Invalid formation year %d", *year)
+		c.String(http.StatusBadRequest, "invalid formed_year")
+		return nil, false
+	}
+	return artist, true
+}
+
+// parseArtistID извлекает идентификатор исполнителя из пути запроса
+func parseArtistID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Printf("ERROR: Invalid artist ID %s", c.Param("id"))
+		c.String(http.StatusBadRequest, "invalid artist id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// findArtist ищет исполнителя по ID и отвечает 404/500, если получить его не удалось
+func (arc *ArtistController) findArtist(c *gin.Context, id uint) (*models.Artist, bool) {
+	artist, err := arc.Store.GetArtistByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrArtistNotFound) {
+			c.String(http.StatusNotFound, "not found")
+			return nil, false
+		}
+		log.Printf("ERROR: Failed to retrieve artist with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	return artist, true
+}
+
+// refreshArtistSongs обновляет подсказки для песен переименованного исполнителя
+func (arc *ArtistController) refreshArtistSongs(id uint) {
+	for page := 1; ; page++ {
+		result, err := arc.Songs.GetAllSongs(repository.SongQuery{
+			Filter: repository.SongFilter{ArtistID: id},
+			Page:   page,
+			Limit:  maxPageLimit,
+		})
+		if err != nil {
+			log.Printf("WARNING: Failed to refresh autocomplete for artist ID %d: %v", id, err)
+			return
+		}
+		for _, song := range result.Songs {
+			arc.Autocomplete.Put(song)
+		}
+		if !result.HasNext {
+			return
+		}
+	}
+}
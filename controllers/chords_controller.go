@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/chordpro"
+	"go-tunes/models"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportChordPro stores a ChordPro chord sheet for a song
+// @Summary Import a ChordPro chord sheet
+// @Description Upload a ChordPro document for a song, either as the raw request body or as a multipart "file" field. Chords, directives and sections are validated; problems are returned with line numbers.
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param chords body string false "ChordPro document"
+// @Param file formData file false "ChordPro file"
+// @Success 200 {object} models.ChordSheet
+// @Failure 400 {object} models.InvalidLyrics
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/chords [put]
+func (sc *SongController) ImportChordPro(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+
+	data, err := readLyricsFile(c)
+	if err != nil {
+		log.Printf("ERROR: Failed to read ChordPro file for song ID %d: %v", id, err)
+		c.String(http.StatusBadRequest, "invalid input: "+err.Error())
+		return
+	}
+	sheet, err := chordpro.Parse(data)
+	if err != nil {
+		var validationErr *chordpro.ValidationError
+		if errors.As(err, &validationErr) {
+			log.Printf("ERROR: Invalid ChordPro file for song ID %d: %v", id, err)
+			c.JSON(http.StatusBadRequest, models.InvalidLyrics{Error: "invalid ChordPro", Problems: validationErr.Problems})
+			return
+		}
+		log.Printf("ERROR: Failed to parse ChordPro file for song ID %d: %v", id, err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+
+	song.ChordPro = data
+	if _, err := sc.Store.UpdateSong(song); err != nil {
+		log.Printf("ERROR: Failed to save chord sheet for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Imported chord sheet with %d lines for song ID %d", len(sheet.Lines), id)
+	c.JSON(http.StatusOK, models.ChordSheet{
+		SongID: id,
+		Title:  sheet.Title,
+		Artist: sheet.Artist,
+		Key:    sheet.Key,
+		Capo:   sheet.Capo,
+		Chords: sheet.Chords(),
+	})
+}
+
+// GetChordSheet renders the song's chord sheet
+// @Summary Render a chord sheet
+// @Description Render the song's ChordPro sheet as plain text with chords above the lyrics, as an HTML fragment, or as ChordPro. transpose shifts the sounding pitch by the given number of semitones; capo re-voices the chords for a capo on that fret while keeping the pitch; accidentals selects sharps, flats or the spelling of the resulting key.
+// @Produce plain
+// @Produce html
+// @Param id path int true "Song ID"
+// @Param format query string false "Output format: text, html or chordpro" default(text)
+// @Param transpose query int false "Semitones to transpose by, from -11 to 11" default(0)
+// @Param capo query int false "Capo fret to voice the chords for, from 0 to 11; defaults to the sheet's {capo}"
+// @Param accidentals query string false "Accidentals: auto, sharp or flat" default(auto)
+// @Success 200 {string} string "Rendered chord sheet"
+// @Failure 400 {string} string "invalid parameters"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/chords [get]
+func (sc *SongController) GetChordSheet(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var options chordpro.Options
+	if value := c.Query("transpose"); value != "" {
+		semitones, err := strconv.Atoi(value)
+		if err != nil || semitones < -11 || semitones > 11 {
+			log.Printf("ERROR: Invalid transpose value %q", value)
+			c.String(http.StatusBadRequest, "invalid transpose: expected an integer from -11 to 11")
+			return
+		}
+		options.Semitones = semitones
+	}
+	if value := c.Query("capo"); value != "" {
+		capo, err := strconv.Atoi(value)
+		if err != nil || capo < 0 || capo > 11 {
+			log.Printf("ERROR: Invalid capo value %q", value)
+			c.String(http.StatusBadRequest, "invalid capo: expected an integer from 0 to 11")
+			return
+		}
+		options.Capo = &capo
+	}
+	accidentals, err := chordpro.ParseAccidentals(c.Query("accidentals"))
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		c.String(http.StatusBadRequest, "invalid accidentals: "+err.Error())
+		return
+	}
+	options.Accidentals = accidentals
+	format := c.DefaultQuery("format", "text")
+	if format != "text" && format != "html" && format != "chordpro" {
+		log.Printf("ERROR: Invalid chord sheet format %q", format)
+		c.String(http.StatusBadRequest, "invalid format: expected text, html or chordpro")
+		return
+	}
+
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+	if song.ChordPro == "" {
+		log.Printf("ERROR: Song with ID %d has no chord sheet", id)
+		c.String(http.StatusNotFound, "no chord sheet")
+		return
+	}
+	sheet, err := chordpro.Parse(song.ChordPro)
+	if err != nil {
+		log.Printf("ERROR: Stored chord sheet for song ID %d is invalid: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	sheet = chordpro.Transpose(sheet, options)
+
+	switch format {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(chordpro.RenderHTML(sheet)))
+	case "chordpro":
+		filename := strconv.Quote(fmt.Sprintf("%s - %s.cho", song.Group, song.Song))
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(chordpro.Format(sheet)))
+	default:
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(chordpro.RenderText(sheet)))
+	}
+}
+
+// DeleteChordPro removes the chord sheet from a song
+// @Summary Delete a chord sheet
+// @Description Remove the song's ChordPro chord sheet; the lyrics are kept
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/chords [delete]
+func (sc *SongController) DeleteChordPro(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+	if song.ChordPro == "" {
+		log.Printf("ERROR: Song with ID %d has no chord sheet", id)
+		c.String(http.StatusNotFound, "no chord sheet")
+		return
+	}
+
+	song.ChordPro = ""
+	if _, err := sc.Store.UpdateSong(song); err != nil {
+		log.Printf("ERROR: Failed to delete chord sheet for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted chord sheet for song ID %d", id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "chord sheet deleted"})
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSongCredits lists the credits of a song
+// @Summary List song credits
+// @Description Retrieve the composers, lyricists, producers and featured artists of a song ordered by role and name
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} models.SongCredit
+// @Failure 400 {string} string "invalid song id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/credits [get]
+func (sc *SongController) GetSongCredits(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+	songCredits, err := sc.Credits.GetSongCredits(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve credits for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, songCredits)
+}
+
+// AddSongCredit credits an artist on a song
+// @Summary Add a song credit
+// @Description Credit a person or group on a song as composer, lyricist, producer or featured artist. The artist is found by name or alias ignoring case and transliteration, and created if unknown. Adding an existing credit returns it with status 200.
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param credit body models.CreditRequest true "Credit"
+// @Success 200 {object} models.SongCredit
+// @Success 201 {object} models.SongCredit
+// @Failure 400 {string} string "invalid input"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/credits [post]
+func (sc *SongController) AddSongCredit(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	var request models.CreditRequest
+	if err := c.ShouldBindJSON(&request); err != nil || strings.TrimSpace(request.Name) == "" {
+		log.Printf("ERROR: Invalid credit data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+
+	credit, created, err := sc.Credits.AddSongCredit(id, request.Name, request.Role)
+	if errors.Is(err, repository.ErrSongNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to add credit for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, credit)
+}
+
+// RemoveSongCredit removes a credit from a song
+// @Summary Remove a song credit
+// @Description Remove the credit of an artist with the given role from a song; the artist itself is kept
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param artist_id path int true "Artist ID"
+// @Param role path string true "Role" Enums(composer, lyricist, producer, featured)
+// @Success 204
+// @Failure 400 {string} string "invalid song id, artist id or role"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/credits/{artist_id}/{role} [delete]
+func (sc *SongController) RemoveSongCredit(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 0)
+	if err != nil {
+		c.String(http.StatusBadRequest, "invalid artist id")
+		return
+	}
+	role, ok := parseCreditRole(c, c.Param("role"))
+	if !ok {
+		return
+	}
+
+	err = sc.Credits.RemoveSongCredit(id, uint(artistID), role)
+	if errors.Is(err, repository.ErrCreditNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to remove credit from song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseCreditRole проверяет название роли участника песни
+func parseCreditRole(c *gin.Context, value string) (models.CreditRole, bool) {
+	switch role := models.CreditRole(value); role {
+	case models.CreditComposer, models.CreditLyricist, models.CreditProducer, models.CreditFeatured:
+		return role, true
+	}
+	log.Printf("ERROR: Invalid credit role %q", value)
+	c.String(http.StatusBadRequest, "invalid role: expected composer, lyricist, producer or featured")
+	return "", false
+}
+
+// creditFeatured отмечает приглашённых исполнителей песни; ошибки не мешают ответу и только записываются в журнал
+func (sc *SongController) creditFeatured(song *models.Song, featured []string) {
+	for _, name := range featured {
+		if _, _, err := sc.Credits.AddSongCredit(song.ID, name, models.CreditFeatured); err != nil {
+			log.Printf("WARNING: Failed to credit featured artist '%s' on song ID %d: %v", name, song.ID, err)
+		}
+	}
+}
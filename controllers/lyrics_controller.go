@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/lrc"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxLyricsFileSize ограничивает размер загружаемого файла с текстом песни
+const maxLyricsFileSize = 1 << 20
+
+// ImportLRC imports time-synced lyrics from an LRC file
+// @Summary Import synced lyrics
+// @Description Upload LRC or enhanced LRC (word timestamps) lyrics for a song, either as the raw request body or as a multipart "file" field. Timestamps are validated; the lyrics are stored normalized and returned parsed.
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lyrics body string false "LRC file contents"
+// @Param file formData file false "LRC file"
+// @Success 200 {object} models.SyncedLyrics
+// @Failure 400 {object} models.InvalidLyrics
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/lyrics/lrc [put]
+func (sc *SongController) ImportLRC(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+
+	data, err := readLyricsFile(c)
+	if err != nil {
+		log.Printf("ERROR: Failed to read LRC file for song ID %d: %v", id, err)
+		c.String(http.StatusBadRequest, "invalid input: "+err.Error())
+		return
+	}
+	lyrics, err := lrc.Parse(data)
+	if err != nil {
+		var validationErr *lrc.ValidationError
+		if errors.As(err, &validationErr) {
+			log.Printf("ERROR: Invalid LRC file for song ID %d: %v", id, err)
+			c.JSON(http.StatusBadRequest, models.InvalidLyrics{Error: "invalid LRC", Problems: validationErr.Problems})
+			return
+		}
+		log.Printf("ERROR: Failed to parse LRC file for song ID %d: %v", id, err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+
+	if err := sc.Store.UpdateSyncedLyrics(id, lrc.Format(lyrics)); err != nil {
+		if errors.Is(err, repository.ErrSongNotFound) {
+			log.Printf("ERROR: Song with ID %d not found", id)
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+		log.Printf("ERROR: Failed to save synced lyrics for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Imported %d synced lines for song ID %d", len(lyrics.Lines), id)
+	c.JSON(http.StatusOK, lyrics)
+}
+
+// ExportLRC exports time-synced lyrics as an LRC file
+// @Summary Export synced lyrics
+// @Description Download the song's synced lyrics as an LRC file
+// @Produce plain
+// @Param id path int true "Song ID"
+// @Success 200 {string} string "LRC file"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/lyrics/lrc [get]
+func (sc *SongController) ExportLRC(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+	if song.SyncedLyrics == "" {
+		log.Printf("ERROR: Song with ID %d has no synced lyrics", id)
+		c.String(http.StatusNotFound, "no synced lyrics")
+		return
+	}
+
+	filename := strconv.Quote(fmt.Sprintf("%s - %s.lrc", song.Group, song.Song))
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(song.SyncedLyrics))
+}
+
+// DeleteLRC removes time-synced lyrics from a song
+// @Summary Delete synced lyrics
+// @Description Remove the song's synced lyrics; the plain text is kept
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/lyrics/lrc [delete]
+func (sc *SongController) DeleteLRC(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+	if song.SyncedLyrics == "" {
+		log.Printf("ERROR: Song with ID %d has no synced lyrics", id)
+		c.String(http.StatusNotFound, "no synced lyrics")
+		return
+	}
+
+	if err := sc.Store.UpdateSyncedLyrics(id, ""); err != nil {
+		if errors.Is(err, repository.ErrSongNotFound) {
+			log.Printf("ERROR: Song with ID %d not found", id)
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+		log.Printf("ERROR: Failed to delete synced lyrics for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted synced lyrics for song ID %d", id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "synced lyrics deleted"})
+}
+
+// GetLyricsAt returns the lyric lines for a playback position
+// @Summary Get lyrics at a playback position
+// @Description Return the current and next synced lines for a playback position in milliseconds (with the LRC offset applied), and the current word for enhanced LRC lines
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param ms query int true "Playback position in milliseconds"
+// @Success 200 {object} models.LyricsPosition
+// @Failure 400 {string} string "invalid position"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/lyrics/at [get]
+func (sc *SongController) GetLyricsAt(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	position, err := strconv.ParseInt(c.Query("ms"), 10, 64)
+	if err != nil || position < 0 {
+		log.Printf("ERROR: Invalid playback position %q", c.Query("ms"))
+		c.String(http.StatusBadRequest, "invalid position: ms must be a non-negative integer")
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+	if song.SyncedLyrics == "" {
+		log.Printf("ERROR: Song with ID %d has no synced lyrics", id)
+		c.String(http.StatusNotFound, "no synced lyrics")
+		return
+	}
+
+	lyrics, err := lrc.Parse(song.SyncedLyrics)
+	if err != nil {
+		log.Printf("ERROR: Stored synced lyrics for song ID %d are invalid: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	current, next, wordIndex := lrc.At(lyrics, position)
+	c.JSON(http.StatusOK, models.LyricsPosition{
+		SongID:     id,
+		PositionMs: position,
+		Current:    current,
+		Next:       next,
+		WordIndex:  wordIndex,
+	})
+}
+
+// readLyricsFile читает файл из поля формы "file" multipart-запроса или из тела запроса
+func readLyricsFile(c *gin.Context) (string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxLyricsFileSize)
+	var reader io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return "", err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		reader = file
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -1,291 +1,529 @@
 package controllers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"go-tunes/database"
+	"go-tunes/analysis"
+	"go-tunes/autocomplete"
+	"go-tunes/catalog"
+	"go-tunes/credits"
+	"go-tunes/enrichment"
+	"go-tunes/fuzzy"
 	"go-tunes/models"
-	"io"
+	"go-tunes/repository"
 	"log"
+	"math"
 	"net/http"
-	"net/url"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
 )
 
-type SongEnrichment struct {
-	Group       string `json:"group"`
-	Song        string `json:"song"`
-	ReleaseDate string `json:"release_date"`
-	Text        string `json:"text"`
-	Link        string `json:"link"`
+// SongController обрабатывает HTTP-запросы, связанные с песнями
+type SongController struct {
+	Store repository.SongStore
+	// Credits — участники песен; приглашённые исполнители из названий отмечаются при добавлении песни
+	Credits repository.CreditStore
+	// Albums — альбомы, на которых вышли песни, для списков песен
+	Albums SongAlbumFinder
+	// Genres — поиск жанра для фильтра genre
+	Genres   GenreFinder
+	Catalog  *catalog.Catalog
+	Enricher enrichment.Enricher
+	Matcher  fuzzy.Matcher
+	// Autocomplete — индекс подсказок; обработчики обновляют его при добавлении, изменении и удалении песен
+	Autocomplete *autocomplete.Index
+	// Analysis — кеш анализа текстов; результат пересчитывается, когда меняется текст песни
+	Analysis *analysis.Cache
+}
+
+func NewSongController(store repository.SongStore, creditStore repository.CreditStore, albums SongAlbumFinder, genres GenreFinder, enrichmentCatalog *catalog.Catalog, enricher enrichment.Enricher, matcher fuzzy.Matcher, index *autocomplete.Index, analysisCache *analysis.Cache) *SongController {
+	return &SongController{
+		Store:        store,
+		Credits:      creditStore,
+		Albums:       albums,
+		Genres:       genres,
+		Catalog:      enrichmentCatalog,
+		Enricher:     enricher,
+		Matcher:      matcher,
+		Autocomplete: index,
+		Analysis:     analysisCache,
+	}
 }
 
 // GetSongInfo обрабатывает запросы для получения информации о песне и добавляет её в базу данных при отсутствии
 // @Summary Get song details
-// @Description Retrieve detailed information about a song, add to database if not present
+// @Description Retrieve detailed information about a song, add to database if not present. Names are matched tolerating typos, case and punctuation; if the song is unknown, the 404 response suggests similar songs. Featured artists given after "feat.", "ft." or "featuring" (e.g. "Ania feat. Bob", "Gamma (ft. Carl & Dan)") are separated from the names and credited on the song; "," and "&" without these markers are part of the name (e.g. "Earth, Wind & Fire").
 // @Produce json
 // @Param group query string true "Group"
 // @Param song query string true "Song"
 // @Success 200 {object} models.SongDetail
 // @Failure 400 {string} string "bad request"
+// @Failure 404 {object} models.SongNotFound
 // @Failure 500 {string} string "internal server error"
+// @Failure 502 {string} string "external API error"
+// @Failure 503 {string} string "external API unavailable"
 // @Router /info [get]
-func GetSongInfo(c *gin.Context) {
-	group := c.Query("group")
-	song := c.Query("song")
+func (sc *SongController) GetSongInfo(c *gin.Context) {
+	group := strings.TrimSpace(c.Query("group"))
+	song := strings.TrimSpace(c.Query("song"))
 
-	// Проверка, что параметры не пусты
+	// Проверка, что параметры не пусты (в том числе не состоят из одних пробелов)
 	if group == "" || song == "" {
 		log.Printf("ERROR: Bad request, missing 'group' or 'song' query parameters")
 		c.String(http.StatusBadRequest, "bad request: missing required parameters")
 		return
 	}
 
-	// Подключаемся к базе данных
-	db := database.Connect()
+	// Приглашённые исполнители ("feat.", "ft.", "featuring") отделяются от названий и сохраняются как участники песни
+	featuring := credits.Parse(group, song)
+	if len(featuring.Featured) > 0 {
+		log.Printf("INFO: Parsed '%s' - '%s' as '%s' - '%s' featuring %v", group, song, featuring.Group, featuring.Song, featuring.Featured)
+		group, song = featuring.Group, featuring.Song
+	}
 
-	// Ищем песню в базе данных
-	var songRecord models.Song
-	if err := db.Where("\"group\" = ? AND song = ?", group, song).First(&songRecord).Error; err != nil {
+	// Ищем песню в хранилище, допуская опечатки в названиях
+	songRecord, suggestions, err := sc.resolveSong(group, song)
+	if err != nil {
+		log.Printf("ERROR: Failed to look up song: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if songRecord == nil {
 		log.Printf("INFO: Song with group '%s' and song '%s' not found in database. Attempting to add it.", group, song)
 
 		// Если песни нет в базе данных, попробуем добавить её, используя внешний API
-		songDetail, shouldReturn := GetSongDetailFromAPI(group, song, c)
-		if shouldReturn {
+		songDetail, err := sc.Enricher.GetSongDetail(c.Request.Context(), group, song)
+		if err != nil {
+			respondEnrichmentError(c, err, suggestions)
 			return
 		}
 
-		// Добавляем песню в базу данных
-		newSong := models.Song{
-			Group:       group,
-			Song:        song,
-			ReleaseDate: songDetail.ReleaseDate,
-			Text:        songDetail.Text,
-			Link:        songDetail.Link,
-		}
-
-		if err := db.Create(&newSong).Error; err != nil {
+		// Добавляем песню в хранилище; если её уже добавил параллельный запрос, получим существующую запись
+		newSong, created, err := sc.Store.FirstOrCreateSong(&models.Song{
+			Group:         group,
+			Song:          song,
+			ReleaseDate:   songDetail.ReleaseDate,
+			Text:          songDetail.Text,
+			Link:          songDetail.Link,
+			TrackMetadata: songDetail.TrackMetadata,
+		})
+		if err != nil {
 			log.Printf("ERROR: Failed to add new song to the database: %v", err)
 			c.String(http.StatusInternalServerError, "internal server error")
 			return
 		}
 
-		log.Printf("INFO: Added new song to the database: %v", newSong)
+		if created {
+			log.Printf("INFO: Added new song to the database: %v", *newSong)
+			sc.Autocomplete.Put(*newSong)
+		}
 		songRecord = newSong
 	}
+	sc.creditFeatured(songRecord, featuring.Featured)
+	sc.Autocomplete.Touch(songRecord.ID)
 
 	// Формируем объект ответа
 	songDetail := models.SongDetail{
-		ReleaseDate: songRecord.ReleaseDate,
-		Text:        songRecord.Text,
-		Link:        songRecord.Link,
+		ReleaseDate:   songRecord.ReleaseDate,
+		Text:          songRecord.Text,
+		Link:          songRecord.Link,
+		TrackMetadata: songRecord.TrackMetadata,
 	}
 
-	// Дополнительное обогащение данных с использованием JSON-файла
-	enrichSongFromJSON(&songDetail, group, song)
+	// Дополнительное обогащение данных из локального каталога
+	sc.enrichFromCatalog(&songDetail, songRecord.Group, songRecord.Song)
 
 	// Возвращаем результат
 	c.JSON(http.StatusOK, songDetail)
 }
 
-// getSongDetailFromAPI выполняет запрос к внешнему API для получения данных о песне
-func GetSongDetailFromAPI(group, song string, c *gin.Context) (models.SongDetail, bool) {
-	encodedGroup := url.QueryEscape(group)
-	encodedSong := url.QueryEscape(song)
-	apiURL := fmt.Sprintf("http://localhost:8081/info?group=%s&song=%s", encodedGroup, encodedSong)
-	response, err := http.Get(apiURL)
+// CreateSong добавляет новую песню, обогащая её данными из внешнего API
+// @Summary Add a new song
+// @Description Add a new song by group and title, enriching it with details from the external API. A song whose names differ from an existing one only by typos, case or punctuation is considered a duplicate.
+// @Accept json
+// @Produce json
+// @Param song body models.NewSongRequest true "New song"
+// @Success 201 {object} models.Song
+// @Failure 400 {string} string "invalid input"
+// @Failure 404 {object} models.SongNotFound
+// @Failure 409 {string} string "song already exists"
+// @Failure 500 {string} string "internal server error"
+// @Failure 502 {string} string "external API error"
+// @Failure 503 {string} string "external API unavailable"
+// @Router /songs [post]
+func (sc *SongController) CreateSong(c *gin.Context) {
+	var request models.NewSongRequest
+	err := c.ShouldBindJSON(&request)
+	request.Group, request.Song = strings.TrimSpace(request.Group), strings.TrimSpace(request.Song)
+	if err != nil || request.Group == "" || request.Song == "" {
+		log.Printf("ERROR: Invalid new song data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+
+	// Проверяем, что такой песни (в том числе записанной с опечаткой) ещё нет в хранилище
+	existing, suggestions, err := sc.resolveSong(request.Group, request.Song)
 	if err != nil {
-		log.Printf("ERROR: Failed to request external API: %v", err)
+		log.Printf("ERROR: Failed to check song existence: %v", err)
 		c.String(http.StatusInternalServerError, "internal server error")
-		return models.SongDetail{}, true
+		return
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		log.Printf("WARNING: External API returned status code %d", response.StatusCode)
-		c.String(http.StatusInternalServerError, "failed to retrieve song details from external API")
-		return models.SongDetail{}, true
+	if existing != nil {
+		log.Printf("WARNING: Song with group '%s' and song '%s' already exists with ID %d", request.Group, request.Song, existing.ID)
+		c.Header("Location", fmt.Sprintf("/songs/%d", existing.ID))
+		c.String(http.StatusConflict, "song already exists")
+		return
 	}
 
-	var apiData models.SongDetail
-	body, err := io.ReadAll(response.Body)
+	// Получаем подробности о песне из внешнего API
+	songDetail, err := sc.Enricher.GetSongDetail(c.Request.Context(), request.Group, request.Song)
 	if err != nil {
-		log.Printf("ERROR: Failed to read API response: %v", err)
-		c.String(http.StatusInternalServerError, "internal server error")
-		return models.SongDetail{}, true
+		respondEnrichmentError(c, err, suggestions)
+		return
 	}
 
-	if err := json.Unmarshal(body, &apiData); err != nil {
-		log.Printf("ERROR: Failed to parse API response: %v", err)
+	newSong, created, err := sc.Store.FirstOrCreateSong(&models.Song{
+		Group:         request.Group,
+		Song:          request.Song,
+		ReleaseDate:   songDetail.ReleaseDate,
+		Text:          songDetail.Text,
+		Link:          songDetail.Link,
+		TrackMetadata: songDetail.TrackMetadata,
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to add new song to the database: %v", err)
 		c.String(http.StatusInternalServerError, "internal server error")
-		return models.SongDetail{}, true
+		return
+	}
+	if !created {
+		// Песню успели добавить параллельным запросом
+		log.Printf("WARNING: Song with group '%s' and song '%s' already exists", request.Group, request.Song)
+		c.String(http.StatusConflict, "song already exists")
+		return
 	}
 
-	return apiData, false
+	log.Printf("INFO: Created song with ID %d", newSong.ID)
+	sc.Autocomplete.Put(*newSong)
+	c.Header("Location", fmt.Sprintf("/songs/%d", newSong.ID))
+	c.JSON(http.StatusCreated, newSong)
 }
 
-// GetSongDetailFromJSON читает данные о песне из JSON-файла
-func GetSongDetailFromJSON(group, song string) (models.SongDetail, error) {
-	jsonFile, err := os.Open("song_enrichment.json")
-	if err != nil {
-		log.Printf("ERROR: Could not open JSON file: %v", err)
-		return models.SongDetail{}, fmt.Errorf("could not open JSON file")
+// resolveSong ищет песню по точному совпадению названий, а затем среди похожих.
+// Если подходящей песни нет, возвращает подсказки "возможно, вы имели в виду".
+func (sc *SongController) resolveSong(group, song string) (*models.Song, []models.SongSuggestion, error) {
+	record, err := sc.Store.GetSongByGroupAndSong(group, song)
+	if err == nil {
+		return record, nil, nil
+	}
+	if !errors.Is(err, repository.ErrSongNotFound) {
+		return nil, nil, err
 	}
-	defer jsonFile.Close()
-
-	byteValue, _ := io.ReadAll(jsonFile)
-
-	// Парсинг JSON в структуру
-	var enrichmentData SongEnrichment
 
-	if err := json.Unmarshal(byteValue, &enrichmentData); err != nil {
-		log.Printf("ERROR: Could not parse JSON file: %v", err)
-		return models.SongDetail{}, fmt.Errorf("could not parse JSON file")
+	matches, err := sc.Store.FindSimilarSongs(group, song, sc.Matcher.MaxSuggestions)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Лучший вариант может отличаться числом в названии; тогда песней считается следующий подходящий вариант
+	for _, match := range matches {
+		if sc.Matcher.IsMatch(match.Score) {
+			log.Printf("INFO: Resolved group '%s' and song '%s' to song ID %d ('%s' - '%s')", group, song, match.Song.ID, match.Song.Group, match.Song.Song)
+			return &match.Song, nil, nil
+		}
 	}
 
-	if enrichmentData.Group == group && enrichmentData.Song == song {
-		return models.SongDetail{
-			ReleaseDate: enrichmentData.ReleaseDate,
-			Text:        enrichmentData.Text,
-			Link:        enrichmentData.Link,
-		}, nil
+	suggestions := make([]models.SongSuggestion, 0, len(matches))
+	for _, match := range matches {
+		if sc.Matcher.IsSuggestion(match.Score) {
+			suggestions = append(suggestions, models.SongSuggestion{
+				ID:    match.Song.ID,
+				Group: match.Song.Group,
+				Song:  match.Song.Song,
+				Score: math.Round(match.Score.Total()*100) / 100,
+			})
+		}
 	}
+	return nil, suggestions, nil
+}
 
-	return models.SongDetail{}, fmt.Errorf("song not found")
+// respondEnrichmentError переводит ошибку внешнего API в HTTP-ответ.
+// Ответ 404 содержит подсказки с похожими песнями из хранилища.
+func respondEnrichmentError(c *gin.Context, err error, suggestions []models.SongSuggestion) {
+	switch {
+	case errors.Is(err, enrichment.ErrNotFound):
+		log.Printf("WARNING: Song not found in external API: %v", err)
+		if suggestions == nil {
+			suggestions = []models.SongSuggestion{}
+		}
+		c.JSON(http.StatusNotFound, models.SongNotFound{Error: "song not found", Suggestions: suggestions})
+	case errors.Is(err, enrichment.ErrCircuitOpen):
+		log.Printf("WARNING: External API is temporarily unavailable: %v", err)
+		c.String(http.StatusServiceUnavailable, "external API unavailable")
+	case errors.Is(err, enrichment.ErrUnavailable):
+		log.Printf("ERROR: External API request failed: %v", err)
+		c.String(http.StatusBadGateway, "failed to retrieve song details from external API")
+	default:
+		log.Printf("ERROR: Failed to retrieve song details: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+	}
 }
 
-// enrichSongFromJSON обогащает данные песни из локального JSON-файла
-func enrichSongFromJSON(songDetail *models.SongDetail, group, song string) {
-	jsonFile, err := os.Open("song_enrichment.json")
-	if err != nil {
-		log.Printf("ERROR: Could not open JSON file: %v", err)
+// enrichFromCatalog обогащает данные песни из локального каталога обогащения
+func (sc *SongController) enrichFromCatalog(songDetail *models.SongDetail, group, song string) {
+	if sc.Catalog == nil {
 		return
 	}
-	defer jsonFile.Close()
-
-	byteValue, _ := io.ReadAll(jsonFile)
-
-	// Парсинг JSON в структуру
-	var enrichmentData SongEnrichment
-	if err := json.Unmarshal(byteValue, &enrichmentData); err != nil {
-		log.Printf("ERROR: Could not parse JSON file: %v", err)
-	} else {
-		// Здесь работа с объектом enrichmentData
-		if enrichmentData.Group == group && enrichmentData.Song == song {
-			songDetail.ReleaseDate = enrichmentData.ReleaseDate
-			songDetail.Text = enrichmentData.Text
-			songDetail.Link = enrichmentData.Link
-		}
+	if enrichment, ok := sc.Catalog.Lookup(group, song); ok {
+		songDetail.ReleaseDate = enrichment.ReleaseDate
+		songDetail.Text = enrichment.Text
+		songDetail.Link = enrichment.Link
+		// Сведения о записи из каталога заменяют сохранённые, только если в каталоге они указаны
+		metadata := enrichment.TrackMetadata
+		metadata.Merge(songDetail.TrackMetadata)
+		songDetail.TrackMetadata = metadata
 	}
 }
 
 // GetSongs retrieves all songs with filtering and pagination
 // @Summary Get all songs
-// @Description Retrieve all songs with optional filtering and pagination
+// @Description Retrieve all songs with optional filtering and pagination. Each song lists the albums it appears on with its track position. Range filters include their bounds and skip songs without the value.
 // @Produce json
 // @Param group query string false "Group"
 // @Param song query string false "Song"
-// @Param release_date query string false "Release Date"
+// @Param release_date query string false "Release date or period: DD.MM.YYYY, YYYY-MM-DD, YYYY-MM, MM.YYYY or YYYY"
+// @Param release_from query string false "Released on or after the start of this date or period"
+// @Param release_to query string false "Released on or before the end of this date or period"
+// @Param year query int false "Release year"
 // @Param text query string false "Text"
 // @Param link query string false "Link"
+// @Param language query string false "Detected lyrics language (ISO 639-1 code, e.g. ru or en)"
+// @Param credited query string false "Part of the name or alias of a person credited on the song in any role"
+// @Param credit_role query string false "Role of the credited person; alone, selects songs with any credit in this role" Enums(composer, lyricist, producer, featured)
+// @Param genre query string false "Genre ID or name; songs of its subgenres are included"
+// @Param tag query []string false "Tag; repeat to require several tags" collectionFormat(multi)
+// @Param duration_min query number false "Minimum duration in seconds"
+// @Param duration_max query number false "Maximum duration in seconds"
+// @Param bpm_min query number false "Minimum tempo in beats per minute"
+// @Param bpm_max query number false "Maximum tempo in beats per minute"
+// @Param key query string false "Key tonic, e.g. C, F# or Bb"
+// @Param mode query string false "Key mode" Enums(major, minor)
+// @Param explicit query bool false "Explicit lyrics flag"
+// @Param isrc query string false "ISRC, with or without hyphens"
+// @Param sort query string false "Comma-separated sort fields, prefix with - for descending (id, group, song, release_date, created_at, updated_at)" example(-release_date,group)
+// @Param cursor query string false "Opaque cursor from next_cursor or prev_cursor of a previous response; takes precedence over page"
 // @Param page query int false "Page number" default(1)
-// @Param limit query int false "Results per page" default(10)
-// @Success 200 {array} models.Song
+// @Param limit query int false "Results per page (at most 100)" default(10)
+// @Success 200 {object} models.SongList
+// @Header 200 {integer} X-Total-Count "Total number of matching songs"
+// @Failure 400 {string} string "invalid filter, sort or cursor"
 // @Failure 500 {string} string "internal server error"
 // @Router /songs [get]
-func GetSongs(c *gin.Context) {
-	db := database.Connect()
-	var songs []models.Song
-
+func (sc *SongController) GetSongs(c *gin.Context) {
 	// Получение параметров фильтрации
-	group := c.Query("group")
-	song := c.Query("song")
-	releaseDate := c.Query("release_date")
-	text := c.Query("text")
-	link := c.Query("link")
-
-	// Получение параметров пагинации
-	page := c.DefaultQuery("page", "1")
-	limit := c.DefaultQuery("limit", "10")
-
-	// Преобразование значений параметров пагинации в int
-	pageNumber, err := strconv.Atoi(page)
-	if err != nil || pageNumber < 1 {
-		pageNumber = 1
+	filter, ok := parseSongFilter(c, sc.Genres)
+	if !ok {
+		return
 	}
 
-	limitNumber, err := strconv.Atoi(limit)
-	if err != nil || limitNumber < 1 {
-		limitNumber = 10
+	// Получение параметров сортировки
+	sortFields, err := repository.ParseSort(c.Query("sort"))
+	if err != nil {
+		log.Printf("ERROR: Invalid sort parameter: %v", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
 	}
 
-	// Построение запроса с учетом фильтров
-	query := db.Model(&models.Song{})
-
-	if group != "" {
-		query = query.Where("\"group\" ILIKE ?", "%"+group+"%")
+	// Получение параметров пагинации: курсор, если передан, имеет приоритет над номером страницы
+	pageNumber, limitNumber := parsePagination(c, 10)
+	var cursor *repository.Cursor
+	if token := c.Query("cursor"); token != "" {
+		if cursor, err = repository.DecodeCursor(token, sortFields); err != nil {
+			log.Printf("ERROR: Invalid cursor: %v", err)
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		pageNumber = 0
 	}
-	if song != "" {
-		query = query.Where("song ILIKE ?", "%"+song+"%")
+
+	// Выполнение запроса
+	result, err := sc.Store.GetAllSongs(repository.SongQuery{
+		Filter: filter,
+		Sort:   sortFields,
+		Page:   pageNumber,
+		Limit:  limitNumber,
+		Cursor: cursor,
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve songs: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
 	}
-	if releaseDate != "" {
-		query = query.Where("release_date = ?", releaseDate)
+	if err := attachAlbums(sc.Albums, result.Songs); err != nil {
+		log.Printf("ERROR: Failed to retrieve albums of songs: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
 	}
-	if text != "" {
-		query = query.Where("text ILIKE ?", "%"+text+"%")
+
+	// Возвращение результатов
+	log.Println("INFO: Retrieved songs with filtering and pagination")
+	songList := models.SongList{
+		Songs:      result.Songs,
+		Total:      result.Total,
+		Page:       pageNumber,
+		Limit:      limitNumber,
+		TotalPages: int((result.Total + int64(limitNumber) - 1) / int64(limitNumber)),
 	}
-	if link != "" {
-		query = query.Where("link ILIKE ?", "%"+link+"%")
+	if len(result.Songs) > 0 {
+		if result.HasNext {
+			songList.NextCursor = repository.EncodeCursor(result.Songs[len(result.Songs)-1], sortFields, false)
+		}
+		if result.HasPrev {
+			songList.PrevCursor = repository.EncodeCursor(result.Songs[0], sortFields, true)
+		}
 	}
+	c.Header("X-Total-Count", strconv.FormatInt(result.Total, 10))
+	c.JSON(http.StatusOK, songList)
+}
 
-	// Пагинация
-	offset := (pageNumber - 1) * limitNumber
-	query = query.Offset(offset).Limit(limitNumber)
+// SearchSongs performs a full-text search over lyrics and titles
+// @Summary Search songs
+// @Description Full-text search over group names, song titles and lyrics with stemming. Results are ranked by relevance; each hit lists the matching verses with highlighted snippets.
+// @Produce json
+// @Param q query string true "Search query: words, \"quoted phrases\", -excluded words, or"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Results per page (at most 100)" default(10)
+// @Success 200 {object} models.SearchResult
+// @Header 200 {integer} X-Total-Count "Total number of matching songs"
+// @Failure 400 {string} string "empty search query"
+// @Failure 500 {string} string "internal server error"
+// @Router /search [get]
+func (sc *SongController) SearchSongs(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	page, limit := parsePagination(c, 10)
 
-	// Выполнение запроса
-	if err := query.Find(&songs).Error; err != nil {
-		log.Printf("ERROR: Failed to retrieve songs: %v", err)
+	hits, total, err := sc.Store.SearchSongs(repository.SearchQuery{Query: query, Page: page, Limit: limit})
+	if err != nil {
+		if errors.Is(err, repository.ErrEmptySearchQuery) {
+			log.Printf("ERROR: Empty search query %q", query)
+			c.String(http.StatusBadRequest, "empty search query")
+			return
+		}
+		log.Printf("ERROR: Failed to search songs: %v", err)
 		c.String(http.StatusInternalServerError, "internal server error")
 		return
 	}
 
-	// Возвращение результатов
-	log.Println("INFO: Retrieved songs with filtering and pagination")
-	c.JSON(http.StatusOK, songs)
+	log.Printf("INFO: Found %d songs for query %q", total, query)
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, models.SearchResult{
+		Query:      query,
+		Results:    hits,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// maxAutocompleteLimit ограничивает количество подсказок в одном ответе
+const maxAutocompleteLimit = 50
+
+// Autocomplete suggests group names or song titles while typing
+// @Summary Autocomplete group names and song titles
+// @Description Suggest group names or song titles starting with the typed prefix (at the start of the name or of any word in it), most popular first. Popularity is the number of songs with the name plus the number of requests to them since startup. Matching ignores case, punctuation and Cyrillic/Latin transliteration.
+// @Produce json
+// @Param q query string true "Typed prefix"
+// @Param field query string false "Field to suggest: group or song" default(song)
+// @Param limit query int false "Number of suggestions (at most 50)" default(10)
+// @Success 200 {object} models.AutocompleteResult
+// @Failure 400 {string} string "invalid field or empty query"
+// @Router /autocomplete [get]
+func (sc *SongController) GetAutocomplete(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		log.Printf("ERROR: Empty autocomplete query")
+		c.String(http.StatusBadRequest, "empty query")
+		return
+	}
+	field, err := autocomplete.ParseField(c.DefaultQuery("field", string(autocomplete.FieldSong)))
+	if err != nil {
+		log.Printf("ERROR: Invalid autocomplete field: %v", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	c.JSON(http.StatusOK, models.AutocompleteResult{
+		Query:       query,
+		Field:       string(field),
+		Suggestions: sc.Autocomplete.Suggest(field, query, limit),
+	})
 }
 
 // GetSongTextWithPagination retrieves the text of a song with pagination by verses
 // @Summary Get a song by ID with pagination
-// @Description Retrieve the text of a song by its ID with pagination by verses
+// @Description Retrieve the text of a song by its ID with pagination by sections (verses, choruses, etc.) or by lines. Sections come from [Verse 1] / [Chorus]-style labels or are detected automatically: blocks repeated in the text are choruses.
 // @Produce json
 // @Param id path int true "Song ID"
+// @Param section query string false "Comma-separated section types to return: verse, pre-chorus, chorus, bridge, intro, outro, hook, other"
+// @Param format query string false "text: sections as strings; sections: structured sections; lines: individual lines" Enums(text, sections, lines) default(text)
+// @Param lang query string false "Language code of a stored translation to return instead of the original"
+// @Param with query string false "original: return each translated section (or line) next to the original one with the same index" Enums(original)
 // @Param page query int false "Page number" default(1)
-// @Param limit query int false "Verses per page" default(1)
+// @Param limit query int false "Sections (or lines) per page" default(1)
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {string} string "invalid section type, format or language"
 // @Failure 404 {string} string "not found"
 // @Failure 500 {string} string "internal server error"
 // @Router /songs/{id}/verses [get]
-func GetSongTextWithPagination(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+func (sc *SongController) GetSongTextWithPagination(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+
+	// Разбор параметров отбора частей и формата ответа
+	sectionTypes, err := models.ParseSectionTypes(c.Query("section"))
 	if err != nil {
-		log.Printf("ERROR: Invalid song ID %s", c.Param("id"))
-		c.String(http.StatusBadRequest, "invalid song id")
+		log.Printf("ERROR: Invalid section filter: %v", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	format := c.DefaultQuery("format", "text")
+	if format != "text" && format != "sections" && format != "lines" {
+		log.Printf("ERROR: Invalid verses format %q", format)
+		c.String(http.StatusBadRequest, "invalid format: expected text, sections or lines")
+		return
+	}
+	lang := ""
+	if value := c.Query("lang"); value != "" {
+		if lang, ok = parseLanguage(c, value); !ok {
+			return
+		}
+	}
+	with := c.Query("with")
+	if with != "" && (with != "original" || lang == "") {
+		log.Printf("ERROR: Invalid with parameter %q for language %q", with, lang)
+		c.String(http.StatusBadRequest, "invalid with: expected original together with lang")
 		return
 	}
-	// Подключение к базе данных
-	db := database.Connect()
 
 	// Поиск песни по ID
-	var song models.Song
-	if err := db.Unscoped().First(&song, id).Error; err != nil {
-		log.Printf("ERROR: Song with ID %d not found", id)
-		c.String(http.StatusNotFound, "not found")
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
 		return
 	}
+	sc.Autocomplete.Touch(song.ID)
 
 	// Получение параметров пагинации
 	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -298,11 +536,28 @@ func GetSongTextWithPagination(c *gin.Context) {
 		limit = 1
 	}
 
-	// Разделение текста песни на куплеты (предполагается, что куплеты разделены "\n\n")
-	verses := strings.Split(song.Text, "\n\n")
+	sections := songSections(song)
+	items := verseItems(sections, sectionTypes, format)
+
+	// Вместо оригинала возвращается перевод, а с with=original — перевод рядом с оригиналом
+	if lang != "" {
+		translation, ok := sc.findTranslation(c, id, lang)
+		if !ok {
+			return
+		}
+		translated := translation.Sections
+		if translated == nil {
+			translated = models.ParseSections(translation.Text)
+		}
+		if with == "original" {
+			items = alignedItems(sections, translated, sectionTypes, format)
+		} else {
+			items = verseItems(translated, sectionTypes, format)
+		}
+	}
 
-	// Подсчет общего количества куплетов
-	totalVerses := len(verses)
+	// Подсчет общего количества частей (или строк)
+	totalVerses := len(items)
 	if totalVerses == 0 {
 		log.Printf("ERROR: No verses found for song with ID %d", id)
 		c.String(http.StatusNotFound, "not found")
@@ -320,32 +575,96 @@ func GetSongTextWithPagination(c *gin.Context) {
 		return
 	}
 
-	// Ограничение конечного индекса до общего количества куплетов
+	// Ограничение конечного индекса до общего количества частей
 	if endIndex > totalVerses {
 		endIndex = totalVerses
 	}
 
-	// Извлечение нужных куплетов
-	selectedVerses := verses[startIndex:endIndex]
+	// Извлечение нужных частей
+	selectedVerses := items[startIndex:endIndex]
 
 	// Формирование ответа
 	response := map[string]interface{}{
-		"song_id":   id,
-		"page":      page,
-		"limit":     limit,
-		"total":     totalVerses,
-		"verses":    selectedVerses,
+		"song_id":     id,
+		"page":        page,
+		"limit":       limit,
+		"total":       totalVerses,
+		"verses":      selectedVerses,
 		"total_pages": (totalVerses + limit - 1) / limit, // Подсчет общего количества страниц
 	}
+	if lang != "" {
+		response["language"] = lang
+	}
 
 	// Логирование и отправка ответа
 	log.Printf("INFO: Retrieved verses for song ID %d, page %d", id, page)
 	c.JSON(http.StatusOK, response)
 }
 
+// songSections возвращает части песни. Они хранятся вместе с текстом; для записей без них текст разбирается на лету
+func songSections(song *models.Song) models.LyricSections {
+	if song.Sections == nil {
+		return models.ParseSections(song.Text)
+	}
+	return song.Sections
+}
+
+// GetSongAnalysis returns statistics of the song's lyrics
+// @Summary Analyze song lyrics
+// @Description Word, line and section counts, unique-word ratio, most repeated lines, estimated rhyme scheme of each section and estimated reading and singing time. Sections are the same as in GET /songs/{id}/verses. The result is cached until the song's text changes; the X-Cache header reports HIT or MISS.
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} models.LyricsAnalysis
+// @Header 200 {string} X-Cache "HIT if the analysis was served from the cache, otherwise MISS"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/analysis [get]
+func (sc *SongController) GetSongAnalysis(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
+		return
+	}
+
+	result, cached := sc.Analysis.Get(song, songSections(song))
+	if cached {
+		c.Header("X-Cache", "HIT")
+	} else {
+		c.Header("X-Cache", "MISS")
+		log.Printf("INFO: Analyzed lyrics of song ID %d", id)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// verseItems отбирает части песни указанных типов и представляет их в запрошенном формате:
+// text — текст части, sections — часть целиком, lines — отдельные строки.
+// Номера частей считаются по всей песне, а не по отобранным частям.
+func verseItems(sections models.LyricSections, types []models.SectionType, format string) []interface{} {
+	items := make([]interface{}, 0, len(sections))
+	for i, section := range sections {
+		if len(types) > 0 && !slices.Contains(types, section.Type) {
+			continue
+		}
+		switch format {
+		case "sections":
+			items = append(items, models.VerseSection{Index: i + 1, LyricSection: section})
+		case "lines":
+			for j, line := range section.Lines {
+				items = append(items, models.LyricLine{Section: i + 1, Type: section.Type, Label: section.Label, Line: j + 1, Text: line})
+			}
+		default:
+			items = append(items, section.Text())
+		}
+	}
+	return items
+}
+
 // UpdateSong updates an existing song
 // @Summary Update a song
-// @Description Update an existing song by its ID
+// @Description Update an existing song by its ID. Track metadata fields omitted from the body keep their values and null clears them; an invalid ISRC, key, mode, duration or BPM is rejected.
 // @Accept json
 // @Produce json
 // @Param id path int true "Song ID"
@@ -353,47 +672,373 @@ func GetSongTextWithPagination(c *gin.Context) {
 // @Success 200 {object} models.Song
 // @Failure 404 {string} string "not found"
 // @Failure 400 {string} string "invalid input"
+// @Failure 409 {string} string "song already exists"
 // @Router /songs/{id} [put]
-func UpdateSong(c *gin.Context) {
-	id := c.Param("id")
-	var song models.Song
-	db := database.Connect()
-	if err := db.First(&song, id).Error; err != nil {
-		log.Printf("ERROR: Song with ID %s not found", id)
-		c.String(http.StatusNotFound, "not found")
+func (sc *SongController) UpdateSong(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, ok := findSong(c, sc.Store, id)
+	if !ok {
 		return
 	}
-	if err := c.ShouldBindJSON(&song); err != nil {
+	deletedAt := song.DeletedAt
+	// Тело запроса разбирается поверх найденной песни: сведения о записи копируются, чтобы не изменить сохранённые значения
+	song.TrackMetadata = song.TrackMetadata.Clone()
+	err := c.ShouldBindJSON(song)
+	song.Group, song.Song = strings.TrimSpace(song.Group), strings.TrimSpace(song.Song)
+	if err != nil || song.Group == "" || song.Song == "" {
 		log.Printf("ERROR: Invalid song data: %v", err)
 		c.String(http.StatusBadRequest, "invalid input")
 		return
 	}
-	if err := db.Save(&song).Error; err != nil {
-		log.Printf("ERROR: Failed to update song with ID %s: %v", id, err)
+	if err := song.TrackMetadata.Normalize(); err != nil {
+		log.Printf("ERROR: Invalid track metadata of song with ID %d: %v", id, err)
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	// Идентификатор берётся из пути, а не из тела запроса; удаление выполняется только через DELETE
+	song.ID = id
+	song.DeletedAt = deletedAt
+	updated, err := sc.Store.UpdateSong(song)
+	if errors.Is(err, repository.ErrSongExists) {
+		log.Printf("WARNING: Song with group '%s' and song '%s' already exists", song.Group, song.Song)
+		c.String(http.StatusConflict, "song already exists")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to update song with ID %d: %v", id, err)
 		c.String(http.StatusInternalServerError, "internal server error")
 		return
 	}
-	log.Printf("INFO: Updated song with ID %s", id)
-	c.JSON(http.StatusOK, song)
+	log.Printf("INFO: Updated song with ID %d", id)
+	sc.Autocomplete.Put(*updated)
+	c.JSON(http.StatusOK, updated)
 }
 
 // DeleteSong deletes a song by ID
 // @Summary Delete a song
-// @Description Delete a song by its ID
+// @Description Move a song to the trash by its ID, or delete it permanently with purge=true
 // @Produce json
 // @Param id path int true "Song ID"
+// @Param purge query bool false "Delete permanently, including songs in the trash"
 // @Success 200 {object} map[string]interface{}
 // @Failure 404 {string} string "not found"
 // @Failure 500 {string} string "internal server error"
 // @Router /songs/{id} [delete]
-func DeleteSong(c *gin.Context) {
-	id := c.Param("id")
-	db := database.Connect()
-	if err := db.Delete(&models.Song{}, id).Error; err != nil {
-		log.Printf("ERROR: Failed to delete song with ID %s: %v", id, err)
+func (sc *SongController) DeleteSong(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+
+	purge, _ := strconv.ParseBool(c.DefaultQuery("purge", "false"))
+	if purge {
+		sc.purgeSong(c, id)
+		return
+	}
+
+	if err := sc.Store.DeleteSong(id); err != nil {
+		if errors.Is(err, repository.ErrSongNotFound) {
+			log.Printf("ERROR: Song with ID %d not found", id)
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+		log.Printf("ERROR: Failed to delete song with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted song with ID %d", id)
+	sc.Autocomplete.Remove(id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "deleted"})
+}
+
+// purgeSong окончательно удаляет песню
+func (sc *SongController) purgeSong(c *gin.Context, id uint) {
+	if err := sc.Store.PurgeSong(id); err != nil {
+		if errors.Is(err, repository.ErrSongNotFound) {
+			log.Printf("ERROR: Song with ID %d not found", id)
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+		log.Printf("ERROR: Failed to purge song with ID %d: %v", id, err)
 		c.String(http.StatusInternalServerError, "internal server error")
 		return
 	}
-	log.Printf("INFO: Deleted song with ID %s", id)
-	c.JSON(http.StatusOK, map[string]interface{}{"id #" + id: "deleted"})
+	log.Printf("INFO: Purged song with ID %d", id)
+	sc.Autocomplete.Remove(id)
+	sc.Analysis.Remove(id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "purged"})
+}
+
+// GetTrash retrieves songs from the trash
+// @Summary Get deleted songs
+// @Description Retrieve songs moved to the trash, most recently deleted first
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Results per page" default(10)
+// @Success 200 {array} models.Song
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/trash [get]
+func (sc *SongController) GetTrash(c *gin.Context) {
+	page, limit := parsePagination(c, 10)
+	songs, err := sc.Store.GetDeletedSongs(page, limit)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve deleted songs: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Println("INFO: Retrieved deleted songs")
+	c.JSON(http.StatusOK, songs)
+}
+
+// RestoreSong restores a song from the trash
+// @Summary Restore a deleted song
+// @Description Restore a song from the trash by its ID
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} models.Song
+// @Failure 404 {string} string "not found"
+// @Failure 409 {string} string "song already exists"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/restore [post]
+func (sc *SongController) RestoreSong(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	song, err := sc.Store.RestoreSong(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSongNotFound):
+			log.Printf("ERROR: Song with ID %d not found in trash", id)
+			c.String(http.StatusNotFound, "not found")
+		case errors.Is(err, repository.ErrSongExists):
+			// За время нахождения в корзине была добавлена песня с той же парой группа+песня
+			log.Printf("WARNING: Cannot restore song with ID %d: group/song pair is taken", id)
+			c.String(http.StatusConflict, "song already exists")
+		default:
+			log.Printf("ERROR: Failed to restore song with ID %d: %v", id, err)
+			c.String(http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	log.Printf("INFO: Restored song with ID %d", id)
+	sc.Autocomplete.Put(*song)
+	c.JSON(http.StatusOK, song)
+}
+
+// parseSongFilter разбирает параметры фильтрации списка песен; при ошибке отвечает 400
+func parseSongFilter(c *gin.Context, genres GenreFinder) (repository.SongFilter, bool) {
+	filter := repository.SongFilter{
+		Group:    c.Query("group"),
+		Song:     c.Query("song"),
+		Text:     c.Query("text"),
+		Link:     c.Query("link"),
+		Credited: c.Query("credited"),
+	}
+	if err := parseReleaseFilter(c, &filter); err != nil {
+		log.Printf("ERROR: Invalid release date filter: %v", err)
+		c.String(http.StatusBadRequest, "invalid release date filter: "+err.Error())
+		return filter, false
+	}
+	if value := c.Query("credit_role"); value != "" {
+		role, ok := parseCreditRole(c, value)
+		if !ok {
+			return filter, false
+		}
+		filter.CreditRole = role
+	}
+	if value := c.Query("language"); value != "" {
+		lang, ok := parseLanguage(c, value)
+		if !ok {
+			return filter, false
+		}
+		// Язык текста определяется без учёта региона: pt-BR ищется как pt
+		base, _ := language.Make(lang).Base()
+		filter.Language = base.String()
+	}
+	if value := c.Query("genre"); value != "" {
+		genre, ok := resolveGenre(c, genres, value)
+		if !ok {
+			return filter, false
+		}
+		filter.GenreID = genre.ID
+	}
+	for _, value := range c.QueryArray("tag") {
+		if tag := models.NormalizeTag(value); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+	if err := parseMetadataFilter(c, &filter); err != nil {
+		log.Printf("ERROR: Invalid track metadata filter: %v", err)
+		c.String(http.StatusBadRequest, err.Error())
+		return filter, false
+	}
+	return filter, true
+}
+
+// parseMetadataFilter разбирает фильтры по сведениям о записи: диапазоны duration_min/duration_max и bpm_min/bpm_max,
+// тональность key и mode, пометку explicit и код isrc
+func parseMetadataFilter(c *gin.Context, filter *repository.SongFilter) error {
+	var err error
+	if filter.Duration, err = parseRange(c, "duration"); err != nil {
+		return err
+	}
+	if filter.BPM, err = parseRange(c, "bpm"); err != nil {
+		return err
+	}
+	if value := c.Query("key"); value != "" {
+		if filter.Key, err = models.ParseKey(value); err != nil {
+			return err
+		}
+	}
+	if value := c.Query("mode"); value != "" {
+		if filter.Mode, err = models.ParseMode(value); err != nil {
+			return err
+		}
+	}
+	if value := c.Query("explicit"); value != "" {
+		explicit, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid explicit %q: expected true or false", value)
+		}
+		filter.Explicit = &explicit
+	}
+	if value := c.Query("isrc"); value != "" {
+		if filter.ISRC, err = models.ParseISRC(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseRange разбирает границы диапазона из параметров <name>_min и <name>_max
+func parseRange(c *gin.Context, name string) (repository.Range, error) {
+	var r repository.Range
+	for _, bound := range []struct {
+		param  string
+		target **float64
+	}{{name + "_min", &r.Min}, {name + "_max", &r.Max}} {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) || number < 0 {
+			return r, fmt.Errorf("invalid %s %q: expected a non-negative number", bound.param, value)
+		}
+		*bound.target = &number
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return r, fmt.Errorf("invalid %s range: %s_min is greater than %s_max", name, name, name)
+	}
+	return r, nil
+}
+
+// parseReleaseFilter переводит параметры release_date, release_from, release_to и year в диапазон дат релиза.
+// Период неточной даты учитывается целиком: release_date=2006 означает весь 2006 год.
+func parseReleaseFilter(c *gin.Context, filter *repository.SongFilter) error {
+	// Пустое значение или значение из одних пробелов разбирается в дату без значения и означает, что параметр не задан
+	date, err := models.ParseReleaseDate(c.Query("release_date"))
+	if err != nil {
+		return err
+	}
+	if date.Date != nil {
+		from, before := *date.Date, date.End()
+		filter.ReleasedFrom = maxTime(filter.ReleasedFrom, &from)
+		filter.ReleasedBefore = minTime(filter.ReleasedBefore, &before)
+	}
+	if date, err = models.ParseReleaseDate(c.Query("release_from")); err != nil {
+		return err
+	}
+	if date.Date != nil {
+		from := *date.Date
+		filter.ReleasedFrom = maxTime(filter.ReleasedFrom, &from)
+	}
+	if date, err = models.ParseReleaseDate(c.Query("release_to")); err != nil {
+		return err
+	}
+	if date.Date != nil {
+		before := date.End()
+		filter.ReleasedBefore = minTime(filter.ReleasedBefore, &before)
+	}
+	if value := c.Query("year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil || year < 1 || year > 9999 {
+			return fmt.Errorf("invalid year %q", value)
+		}
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		before := from.AddDate(1, 0, 0)
+		filter.ReleasedFrom = maxTime(filter.ReleasedFrom, &from)
+		filter.ReleasedBefore = minTime(filter.ReleasedBefore, &before)
+	}
+	return nil
+}
+
+func maxTime(current, candidate *time.Time) *time.Time {
+	if current == nil || candidate.After(*current) {
+		return candidate
+	}
+	return current
+}
+
+func minTime(current, candidate *time.Time) *time.Time {
+	if current == nil || candidate.Before(*current) {
+		return candidate
+	}
+	return current
+}
+
+// maxPageLimit ограничивает количество элементов, возвращаемых за один запрос
+const maxPageLimit = 100
+
+// parsePagination извлекает параметры page и limit, подставляя значения по умолчанию при некорректном вводе
+// и ограничивая limit значением maxPageLimit
+func parsePagination(c *gin.Context, defaultLimit int) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	if err != nil || limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return page, limit
+}
+
+// parseSongID извлекает идентификатор песни из пути запроса
+func parseSongID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Printf("ERROR: Invalid song ID %s", c.Param("id"))
+		c.String(http.StatusBadRequest, "invalid song id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// SongGetter возвращает песню по ID
+type SongGetter interface {
+	GetSongByID(id uint) (*models.Song, error)
+}
+
+// findSong ищет песню по ID и отвечает 404/500, если получить её не удалось
+func findSong(c *gin.Context, songs SongGetter, id uint) (*models.Song, bool) {
+	song, err := songs.GetSongByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSongNotFound) {
+			log.Printf("ERROR: Song with ID %d not found", id)
+			c.String(http.StatusNotFound, "not found")
+			return nil, false
+		}
+		log.Printf("ERROR: Failed to retrieve song with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	return song, true
 }
@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-tunes/analysis"
+	"go-tunes/autocomplete"
+	"go-tunes/fuzzy"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubEnricher отдаёт подробности песни без обращения к внешнему API
+type stubEnricher struct {
+	releaseDates map[string]string
+}
+
+func (e stubEnricher) GetSongDetail(ctx context.Context, group, song string) (models.SongDetail, error) {
+	date, err := models.ParseReleaseDate(e.releaseDates[song])
+	if err != nil {
+		return models.SongDetail{}, err
+	}
+	return models.SongDetail{ReleaseDate: date, Text: "Verse\n\nChorus", Link: "https://example.com/" + song}, nil
+}
+
+func newTestRouter(releaseDates map[string]string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	store := repository.NewMemorySongRepository()
+	songController := NewSongController(store, store, store, store, nil, stubEnricher{releaseDates: releaseDates},
+		fuzzy.DefaultMatcher, autocomplete.NewIndex(), analysis.NewCache(16))
+
+	router := gin.New()
+	router.GET("/info", songController.GetSongInfo)
+	router.GET("/songs", songController.GetSongs)
+	router.POST("/songs", songController.CreateSong)
+	router.PUT("/songs/:id", songController.UpdateSong)
+	router.DELETE("/songs/:id", songController.DeleteSong)
+	router.GET("/songs/trash", songController.GetTrash)
+	router.POST("/songs/:id/restore", songController.RestoreSong)
+	router.PUT("/songs/:id/lyrics/lrc", songController.ImportLRC)
+	router.GET("/songs/:id/lyrics/lrc", songController.ExportLRC)
+	router.DELETE("/songs/:id/lyrics/lrc", songController.DeleteLRC)
+	return router
+}
+
+func serve(router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, url, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func createSong(t *testing.T, router *gin.Engine, group, song string) models.Song {
+	t.Helper()
+	recorder := serve(router, http.MethodPost, "/songs", fmt.Sprintf(`{"group":%q,"song":%q}`, group, song))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("POST /songs %s - %s: status = %d, want %d (%s)", group, song, recorder.Code, http.StatusCreated, recorder.Body)
+	}
+	var created models.Song
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("POST /songs: invalid response body: %v", err)
+	}
+	return created
+}
+
+func listSongs(t *testing.T, router *gin.Engine, url string) models.SongList {
+	t.Helper()
+	recorder := serve(router, http.MethodGet, url, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s: status = %d, want %d (%s)", url, recorder.Code, http.StatusOK, recorder.Body)
+	}
+	var list models.SongList
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("GET %s: invalid response body: %v", url, err)
+	}
+	return list
+}
+
+func songNames(songs []models.Song) []string {
+	names := make([]string, 0, len(songs))
+	for _, song := range songs {
+		names = append(names, song.Song)
+	}
+	return names
+}
+
+func TestCreateSong(t *testing.T) {
+	router := newTestRouter(map[string]string{"Uprising": "2009-09-07"})
+
+	recorder := serve(router, http.MethodPost, "/songs", `{"group":"  Muse ","song":"Uprising "}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d (%s)", recorder.Code, http.StatusCreated, recorder.Body)
+	}
+	var song models.Song
+	if err := json.Unmarshal(recorder.Body.Bytes(), &song); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+	if song.Group != "Muse" || song.Song != "Uprising" {
+		t.Errorf("created song = %q - %q, want trimmed names", song.Group, song.Song)
+	}
+	if got := song.ReleaseDate.String(); got != "2009-09-07" {
+		t.Errorf("release date = %q, want %q", got, "2009-09-07")
+	}
+	if got, want := recorder.Header().Get("Location"), fmt.Sprintf("/songs/%d", song.ID); got != want {
+		t.Errorf("Location = %q, want %q", got, want)
+	}
+}
+
+func TestCreateSongInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed json", `{"group":`},
+		{"missing song", `{"group":"Muse"}`},
+		{"blank group", `{"group":"   ","song":"Uprising"}`},
+		{"blank song", `{"group":"Muse","song":"\t"}`},
+	}
+	router := newTestRouter(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if recorder := serve(router, http.MethodPost, "/songs", tt.body); recorder.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestCreateSongDuplicate(t *testing.T) {
+	router := newTestRouter(nil)
+	existing := createSong(t, router, "Muse", "Supermassive Black Hole")
+	createSong(t, router, "Beethoven", "Symphony No. 5")
+
+	tests := []struct {
+		name       string
+		group      string
+		song       string
+		wantStatus int
+	}{
+		{"same names", "Muse", "Supermassive Black Hole", http.StatusConflict},
+		{"case and punctuation", "MUSE", "supermassive black hole!", http.StatusConflict},
+		{"typo", "Muse", "Supermasive Black Hole", http.StatusConflict},
+		{"other number", "Beethoven", "Symphony No. 9", http.StatusCreated},
+		{"other song", "Muse", "Uprising", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, http.MethodPost, "/songs", fmt.Sprintf(`{"group":%q,"song":%q}`, tt.group, tt.song))
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.wantStatus == http.StatusConflict && tt.group == "Muse" {
+				if got, want := recorder.Header().Get("Location"), fmt.Sprintf("/songs/%d", existing.ID); got != want {
+					t.Errorf("Location = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteAndRestoreSong(t *testing.T) {
+	router := newTestRouter(nil)
+	song := createSong(t, router, "Muse", "Uprising")
+	createSong(t, router, "Muse", "Hysteria")
+	songURL := fmt.Sprintf("/songs/%d", song.ID)
+
+	if recorder := serve(router, http.MethodDelete, songURL, ""); recorder.Code != http.StatusOK {
+		t.Fatalf("DELETE %s: status = %d, want %d", songURL, recorder.Code, http.StatusOK)
+	}
+	if got := songNames(listSongs(t, router, "/songs").Songs); !slices.Equal(got, []string{"Hysteria"}) {
+		t.Errorf("songs after delete = %q, want only Hysteria", got)
+	}
+	var trash []models.Song
+	recorder := serve(router, http.MethodGet, "/songs/trash", "")
+	if err := json.Unmarshal(recorder.Body.Bytes(), &trash); err != nil || len(trash) != 1 || trash[0].ID != song.ID {
+		t.Fatalf("GET /songs/trash = %s, want the deleted song", recorder.Body)
+	}
+	if recorder := serve(router, http.MethodDelete, songURL, ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("second DELETE %s: status = %d, want %d", songURL, recorder.Code, http.StatusNotFound)
+	}
+
+	if recorder := serve(router, http.MethodPost, songURL+"/restore", ""); recorder.Code != http.StatusOK {
+		t.Fatalf("POST %s/restore: status = %d, want %d", songURL, recorder.Code, http.StatusOK)
+	}
+	if got := listSongs(t, router, "/songs").Total; got != 2 {
+		t.Errorf("total after restore = %d, want 2", got)
+	}
+	if recorder := serve(router, http.MethodGet, "/songs/trash", ""); strings.TrimSpace(recorder.Body.String()) != "[]" {
+		t.Errorf("GET /songs/trash = %s, want an empty trash", recorder.Body)
+	}
+	if recorder := serve(router, http.MethodPost, songURL+"/restore", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("second POST %s/restore: status = %d, want %d", songURL, recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestRestoreSongTakenPair(t *testing.T) {
+	router := newTestRouter(nil)
+	song := createSong(t, router, "Muse", "Uprising")
+	serve(router, http.MethodDelete, fmt.Sprintf("/songs/%d", song.ID), "")
+	createSong(t, router, "Muse", "Uprising")
+
+	recorder := serve(router, http.MethodPost, fmt.Sprintf("/songs/%d/restore", song.ID), "")
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusConflict)
+	}
+}
+
+func TestGetSongsCursorPaging(t *testing.T) {
+	router := newTestRouter(nil)
+	names := []string{"Apocalypse Please", "Butterflies and Hurricanes", "Citizen Erased", "Dead Star", "Endlessly"}
+	for _, name := range names {
+		createSong(t, router, "Muse", name)
+	}
+
+	var pages [][]string
+	var prev string
+	list := listSongs(t, router, "/songs?sort=song&limit=2")
+	if list.PrevCursor != "" {
+		t.Errorf("first page prev_cursor = %q, want none", list.PrevCursor)
+	}
+	for {
+		pages = append(pages, songNames(list.Songs))
+		if list.NextCursor == "" {
+			break
+		}
+		list = listSongs(t, router, "/songs?sort=song&limit=2&cursor="+list.NextCursor)
+		if list.PrevCursor == "" {
+			t.Fatalf("page %d has no prev_cursor", len(pages)+1)
+		}
+		prev = list.PrevCursor
+	}
+	want := [][]string{names[0:2], names[2:4], names[4:5]}
+	if !slices.EqualFunc(pages, want, slices.Equal[[]string]) {
+		t.Fatalf("pages = %q, want %q", pages, want)
+	}
+
+	back := listSongs(t, router, "/songs?sort=song&limit=2&cursor="+prev)
+	if got := songNames(back.Songs); !slices.Equal(got, names[2:4]) {
+		t.Errorf("page before the last = %q, want %q", got, names[2:4])
+	}
+
+	for _, url := range []string{"/songs?sort=song&cursor=garbage", "/songs?sort=-song&cursor=" + back.NextCursor} {
+		if recorder := serve(router, http.MethodGet, url, ""); recorder.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want %d", url, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetSongsReleaseFilters(t *testing.T) {
+	router := newTestRouter(map[string]string{
+		"Knights of Cydonia": "2006-07-16",
+		"Starlight":          "09.2006",
+		"Plug In Baby":       "2001",
+		"Uprising":           "2009-09-07",
+	})
+	for _, name := range []string{"Knights of Cydonia", "Starlight", "Plug In Baby", "Uprising", "Undated"} {
+		createSong(t, router, "Muse", name)
+	}
+	all := []string{"Knights of Cydonia", "Starlight", "Plug In Baby", "Uprising", "Undated"}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", all},
+		{"release_date=%20", all},
+		{"release_from=%20&release_to=%20", all},
+		{"year=2006", []string{"Knights of Cydonia", "Starlight"}},
+		{"release_date=2006-07", []string{"Knights of Cydonia"}},
+		{"release_date=16.07.2006", []string{"Knights of Cydonia"}},
+		{"release_date=2001", []string{"Plug In Baby"}},
+		{"release_from=2006-08", []string{"Starlight", "Uprising"}},
+		{"release_to=2006", []string{"Knights of Cydonia", "Starlight", "Plug In Baby"}},
+		{"release_from=2002&release_to=2006-08-01", []string{"Knights of Cydonia"}},
+		{"year=2006&release_from=2006-08-01", []string{"Starlight"}},
+		{"year=2005", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			list := listSongs(t, router, "/songs?limit=10&"+tt.query)
+			got := songNames(list.Songs)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("songs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, query := range []string{"release_date=someday", "release_from=2006-13", "release_to=soon", "year=two", "year=0"} {
+		if recorder := serve(router, http.MethodGet, "/songs?"+query, ""); recorder.Code != http.StatusBadRequest {
+			t.Errorf("GET /songs?%s: status = %d, want %d", query, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetSongInfoKeepsBandNames(t *testing.T) {
+	router := newTestRouter(nil)
+	for _, group := range []string{"Earth, Wind & Fire", "Simon & Garfunkel"} {
+		url := "/info?song=Song&group=" + strings.NewReplacer(" ", "%20", ",", "%2C", "&", "%26").Replace(group)
+		if recorder := serve(router, http.MethodGet, url, ""); recorder.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d, want %d (%s)", url, recorder.Code, http.StatusOK, recorder.Body)
+		}
+	}
+
+	list := listSongs(t, router, "/songs?sort=group")
+	var groups []string
+	for _, song := range list.Songs {
+		groups = append(groups, song.Group)
+	}
+	if want := []string{"Earth, Wind & Fire", "Simon & Garfunkel"}; !slices.Equal(groups, want) {
+		t.Errorf("groups = %q, want %q", groups, want)
+	}
+}
+
+func TestImportAndDeleteLRC(t *testing.T) {
+	router := newTestRouter(map[string]string{"Uprising": "2009-09-07"})
+	song := createSong(t, router, "Muse", "Uprising")
+	lrcURL := fmt.Sprintf("/songs/%d/lyrics/lrc", song.ID)
+
+	if recorder := serve(router, http.MethodPut, lrcURL, "[00:01.00]Paranoia is in bloom"); recorder.Code != http.StatusOK {
+		t.Fatalf("PUT %s: status = %d, want %d (%s)", lrcURL, recorder.Code, http.StatusOK, recorder.Body)
+	}
+	recorder := serve(router, http.MethodGet, lrcURL, "")
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Paranoia is in bloom") {
+		t.Errorf("GET %s = %d %q, want the imported lyrics", lrcURL, recorder.Code, recorder.Body)
+	}
+	list := listSongs(t, router, "/songs")
+	if len(list.Songs) != 1 || list.Songs[0].Text != song.Text || list.Songs[0].ReleaseDate.String() != "2009-09-07" {
+		t.Errorf("song after PUT %s = %+v, want other fields unchanged", lrcURL, list.Songs)
+	}
+
+	if recorder := serve(router, http.MethodDelete, lrcURL, ""); recorder.Code != http.StatusOK {
+		t.Fatalf("DELETE %s: status = %d, want %d", lrcURL, recorder.Code, http.StatusOK)
+	}
+	if recorder := serve(router, http.MethodGet, lrcURL, ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("GET %s after delete: status = %d, want %d", lrcURL, recorder.Code, http.StatusNotFound)
+	}
+	if recorder := serve(router, http.MethodPut, "/songs/999/lyrics/lrc", "[00:01.00]Line"); recorder.Code != http.StatusNotFound {
+		t.Errorf("PUT for a missing song: status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestGetSongsCursorAfterDeletedFirstPage(t *testing.T) {
+	router := newTestRouter(nil)
+	var first []models.Song
+	for _, name := range []string{"Apocalypse Please", "Butterflies and Hurricanes", "Citizen Erased"} {
+		first = append(first, createSong(t, router, "Muse", name))
+	}
+
+	list := listSongs(t, router, "/songs?sort=song&limit=2")
+	for _, song := range first[:2] {
+		serve(router, http.MethodDelete, fmt.Sprintf("/songs/%d", song.ID), "")
+	}
+	next := listSongs(t, router, "/songs?sort=song&limit=2&cursor="+list.NextCursor)
+	if got := songNames(next.Songs); !slices.Equal(got, []string{"Citizen Erased"}) {
+		t.Fatalf("page after cursor = %q, want Citizen Erased", got)
+	}
+	if next.PrevCursor != "" {
+		t.Errorf("prev_cursor = %q, want none when no songs remain before the cursor", next.PrevCursor)
+	}
+}
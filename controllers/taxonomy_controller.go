@@ -0,0 +1,425 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxTagCounts ограничивает количество тегов в ответе GET /tags
+const maxTagCounts = 100
+
+// TaxonomyController обрабатывает HTTP-запросы, связанные с жанрами и тегами
+type TaxonomyController struct {
+	Store repository.TaxonomyStore
+	Songs SongGetter
+}
+
+func NewTaxonomyController(store repository.TaxonomyStore, songs SongGetter) *TaxonomyController {
+	return &TaxonomyController{Store: store, Songs: songs}
+}
+
+// GenreFinder ищет жанр по ID или названию
+type GenreFinder interface {
+	GetGenreByID(id uint) (*models.Genre, error)
+	// FindGenre ищет жанр по названию без учёта регистра, пунктуации и транслитерации
+	FindGenre(name string) (*models.Genre, error)
+}
+
+// CreateGenre adds a new genre
+// @Summary Add a new genre
+// @Description Add a genre, optionally under a parent genre (e.g. Alternative Rock under Rock). Genre names are unique across the tree, ignoring case, punctuation and transliteration.
+// @Accept json
+// @Produce json
+// @Param genre body models.GenreRequest true "Genre"
+// @Success 201 {object} models.Genre
+// @Failure 400 {string} string "invalid input or unknown parent"
+// @Failure 409 {string} string "genre already exists"
+// @Failure 500 {string} string "internal server error"
+// @Router /genres [post]
+func (tc *TaxonomyController) CreateGenre(c *gin.Context) {
+	genre, ok := bindGenre(c)
+	if !ok {
+		return
+	}
+	created, err := tc.Store.CreateGenre(genre)
+	if !respondGenreError(c, err) {
+		return
+	}
+	log.Printf("INFO: Created genre with ID %d", created.ID)
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetGenres retrieves the genre tree
+// @Summary Get the genre tree
+// @Description Retrieve all genres as a tree: top-level genres with nested subgenres, each level ordered by name
+// @Produce json
+// @Success 200 {array} models.Genre
+// @Failure 500 {string} string "internal server error"
+// @Router /genres [get]
+func (tc *TaxonomyController) GetGenres(c *gin.Context) {
+	genres, err := tc.Store.GetGenres()
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve genres: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, models.GenreTree(genres))
+}
+
+// UpdateGenre renames or moves a genre
+// @Summary Update a genre
+// @Description Rename a genre or move it under another parent; without parent_id the genre becomes top-level. A genre cannot be moved under itself or its subgenres.
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param genre body models.GenreRequest true "Updated genre"
+// @Success 200 {object} models.Genre
+// @Failure 400 {string} string "invalid input, unknown parent or cycle"
+// @Failure 404 {string} string "not found"
+// @Failure 409 {string} string "genre already exists"
+// @Failure 500 {string} string "internal server error"
+// @Router /genres/{id} [put]
+func (tc *TaxonomyController) UpdateGenre(c *gin.Context) {
+	id, ok := parseGenreID(c)
+	if !ok {
+		return
+	}
+	genre, ok := bindGenre(c)
+	if !ok {
+		return
+	}
+	genre.ID = id
+	if _, err := tc.Store.GetGenreByID(id); errors.Is(err, repository.ErrGenreNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	updated, err := tc.Store.UpdateGenre(genre)
+	if !respondGenreError(c, err) {
+		return
+	}
+	log.Printf("INFO: Updated genre with ID %d", id)
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteGenre deletes a genre
+// @Summary Delete a genre
+// @Description Delete a genre without subgenres; its songs lose the genre
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {string} string "invalid genre id"
+// @Failure 404 {string} string "not found"
+// @Failure 409 {string} string "genre has subgenres"
+// @Failure 500 {string} string "internal server error"
+// @Router /genres/{id} [delete]
+func (tc *TaxonomyController) DeleteGenre(c *gin.Context) {
+	id, ok := parseGenreID(c)
+	if !ok {
+		return
+	}
+	err := tc.Store.DeleteGenre(id)
+	if errors.Is(err, repository.ErrGenreNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if errors.Is(err, repository.ErrGenreHasChildren) {
+		c.String(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to delete genre with ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted genre with ID %d", id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): "deleted"})
+}
+
+// GetSongGenres lists the genres of a song
+// @Summary List song genres
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} models.Genre
+// @Failure 400 {string} string "invalid song id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/genres [get]
+func (tc *TaxonomyController) GetSongGenres(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, tc.Songs, id); !ok {
+		return
+	}
+	genres, err := tc.Store.GetSongGenres(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve genres for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, genres)
+}
+
+// SetSongGenres replaces the genres of a song
+// @Summary Set song genres
+// @Description Replace the genres of a song; an empty list removes all genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param genres body models.SongGenresRequest true "Genre IDs"
+// @Success 200 {array} models.Genre
+// @Failure 400 {string} string "invalid input or unknown genre"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/genres [put]
+func (tc *TaxonomyController) SetSongGenres(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	var request models.SongGenresRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("ERROR: Invalid genre list: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+	genres, err := tc.Store.SetSongGenres(id, request.GenreIDs)
+	if errors.Is(err, repository.ErrSongNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if errors.Is(err, repository.ErrGenreNotFound) {
+		c.String(http.StatusBadRequest, "genre not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to set genres for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, genres)
+}
+
+// GetSongTags lists the tags of a song
+// @Summary List song tags
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} string
+// @Failure 400 {string} string "invalid song id"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/tags [get]
+func (tc *TaxonomyController) GetSongTags(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, tc.Songs, id); !ok {
+		return
+	}
+	tags, err := tc.Store.GetSongTags(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve tags for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+// AddSongTags adds tags to a song
+// @Summary Add song tags
+// @Description Add user-defined tags to a song and return all its tags. Tags are stored lowercase with single spaces and without a leading #; tags the song already has are kept.
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param tags body models.TagsRequest true "Tags"
+// @Success 200 {array} string
+// @Failure 400 {string} string "invalid tag"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/tags [post]
+func (tc *TaxonomyController) AddSongTags(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	var request models.TagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("ERROR: Invalid tag list: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+	tags := make([]string, 0, len(request.Tags))
+	for _, value := range request.Tags {
+		tag, ok := parseTag(c, value)
+		if !ok {
+			return
+		}
+		tags = append(tags, tag)
+	}
+
+	all, err := tc.Store.AddSongTags(id, tags)
+	if errors.Is(err, repository.ErrSongNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to add tags to song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, all)
+}
+
+// RemoveSongTag removes a tag from a song
+// @Summary Remove a song tag
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param tag path string true "Tag"
+// @Success 204
+// @Failure 400 {string} string "invalid song id or tag"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/tags/{tag} [delete]
+func (tc *TaxonomyController) RemoveSongTag(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	tag, ok := parseTag(c, c.Param("tag"))
+	if !ok {
+		return
+	}
+	err := tc.Store.RemoveSongTag(id, tag)
+	if errors.Is(err, repository.ErrTagNotFound) {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to remove tag from song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTagCounts counts songs by tag for faceting
+// @Summary Get tag counts
+// @Description Count songs by tag, most frequent first. Accepts the filters of GET /songs, so the counts describe the current selection and can be shown as facets.
+// @Produce json
+// @Param prefix query string false "Beginning of the tag"
+// @Param limit query int false "Number of tags (at most 100)" default(20)
+// @Param group query string false "Group"
+// @Param song query string false "Song"
+// @Param genre query string false "Genre ID or name; songs of its subgenres are included"
+// @Param tag query []string false "Tag; repeat to require several tags" collectionFormat(multi)
+// @Param credited query string false "Part of the name or alias of a person credited on the song"
+// @Param language query string false "Detected lyrics language"
+// @Success 200 {array} models.TagCount
+// @Failure 400 {string} string "invalid filter"
+// @Failure 500 {string} string "internal server error"
+// @Router /tags [get]
+func (tc *TaxonomyController) GetTagCounts(c *gin.Context) {
+	filter, ok := parseSongFilter(c, tc.Store)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	counts, err := tc.Store.GetTagCounts(repository.TagQuery{
+		Filter: filter,
+		Prefix: models.NormalizeTag(c.Query("prefix")),
+		Limit:  min(limit, maxTagCounts),
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to count tags: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, counts)
+}
+
+// bindGenre разбирает и проверяет тело запроса с данными жанра
+func bindGenre(c *gin.Context) (*models.Genre, bool) {
+	var request models.GenreRequest
+	if err := c.ShouldBindJSON(&request); err != nil || strings.TrimSpace(request.Name) == "" {
+		log.Printf("ERROR: Invalid genre data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return nil, false
+	}
+	return &models.Genre{Name: strings.TrimSpace(request.Name), ParentID: request.ParentID}, true
+}
+
+// respondGenreError отвечает на ошибку сохранения жанра; возвращает true, если ошибки нет
+func respondGenreError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, repository.ErrGenreExists):
+		c.String(http.StatusConflict, "genre already exists")
+	case errors.Is(err, repository.ErrGenreNotFound):
+		c.String(http.StatusBadRequest, "parent genre not found")
+	case errors.Is(err, repository.ErrGenreCycle):
+		c.String(http.StatusBadRequest, err.Error())
+	default:
+		log.Printf("ERROR: Failed to save genre: %v", err)
+		c.String(http.StatusInternalServerError, "internal server error")
+	}
+	return false
+}
+
+// parseGenreID извлекает идентификатор жанра из пути запроса
+func parseGenreID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Printf("ERROR: Invalid genre ID %s", c.Param("id"))
+		c.String(http.StatusBadRequest, "invalid genre id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// resolveGenre находит жанр по ID или названию; при ошибке отвечает 400 или 500
+func resolveGenre(c *gin.Context, genres GenreFinder, value string) (*models.Genre, bool) {
+	var genre *models.Genre
+	var err error
+	if id, parseErr := strconv.ParseUint(value, 10, 0); parseErr == nil {
+		genre, err = genres.GetGenreByID(uint(id))
+	} else {
+		genre, err = genres.FindGenre(value)
+	}
+	if errors.Is(err, repository.ErrGenreNotFound) {
+		log.Printf("ERROR: Genre %q not found", value)
+		c.String(http.StatusBadRequest, "genre not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to look up genre %q: %v", value, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	return genre, true
+}
+
+// parseTag нормализует тег и проверяет его длину
+func parseTag(c *gin.Context, value string) (string, bool) {
+	tag := models.NormalizeTag(value)
+	if tag == "" || utf8.RuneCountInString(tag) > models.MaxTagLength {
+		log.Printf("ERROR: Invalid tag %q", value)
+		c.String(http.StatusBadRequest, fmt.Sprintf("invalid tag %q: expected 1 to %d characters", value, models.MaxTagLength))
+		return "", false
+	}
+	return tag, true
+}
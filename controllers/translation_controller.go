@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"go-tunes/models"
+	"go-tunes/repository"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// GetTranslations lists the translations of a song
+// @Summary List song translations
+// @Description Retrieve all translations of a song ordered by language code
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} models.Translation
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/translations [get]
+func (sc *SongController) GetTranslations(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+	translations, err := sc.Store.GetTranslations(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve translations for song ID %d: %v", id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
+
+// GetTranslation retrieves the translation of a song into a language
+// @Summary Get a song translation
+// @Description Retrieve the translation of a song into the given language (BCP 47 code such as ru, en or pt-BR)
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "Language code"
+// @Success 200 {object} models.Translation
+// @Failure 400 {string} string "invalid language code"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/translations/{lang} [get]
+func (sc *SongController) GetTranslation(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	lang, ok := parseLanguage(c, c.Param("lang"))
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+	translation, ok := sc.findTranslation(c, id, lang)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+// PutTranslation adds or replaces the translation of a song into a language
+// @Summary Add or replace a song translation
+// @Description Store the translation of a song into the given language together with its provenance: source (official, community or machine), translator and source URL. An existing translation into the same language is replaced.
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "Language code"
+// @Param translation body models.TranslationRequest true "Translation"
+// @Success 200 {object} models.Translation
+// @Success 201 {object} models.Translation
+// @Failure 400 {string} string "invalid input"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/translations/{lang} [put]
+func (sc *SongController) PutTranslation(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	lang, ok := parseLanguage(c, c.Param("lang"))
+	if !ok {
+		return
+	}
+	var request models.TranslationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("ERROR: Invalid translation data: %v", err)
+		c.String(http.StatusBadRequest, "invalid input")
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+
+	translation, created, err := sc.Store.SaveTranslation(&models.Translation{
+		SongID:     id,
+		Language:   lang,
+		Text:       request.Text,
+		Source:     request.Source,
+		Translator: request.Translator,
+		SourceURL:  request.SourceURL,
+	})
+	if errors.Is(err, repository.ErrSongNotFound) {
+		log.Printf("ERROR: Song with ID %d not found", id)
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to save %s translation for song ID %d: %v", lang, id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	log.Printf("INFO: Saved %s translation for song ID %d", lang, id)
+	c.JSON(status, translation)
+}
+
+// DeleteTranslation removes the translation of a song into a language
+// @Summary Delete a song translation
+// @Description Remove the translation of a song into the given language
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "Language code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {string} string "invalid language code"
+// @Failure 404 {string} string "not found"
+// @Failure 500 {string} string "internal server error"
+// @Router /songs/{id}/translations/{lang} [delete]
+func (sc *SongController) DeleteTranslation(c *gin.Context) {
+	id, ok := parseSongID(c)
+	if !ok {
+		return
+	}
+	lang, ok := parseLanguage(c, c.Param("lang"))
+	if !ok {
+		return
+	}
+	if _, ok := findSong(c, sc.Store, id); !ok {
+		return
+	}
+	err := sc.Store.DeleteTranslation(id, lang)
+	if errors.Is(err, repository.ErrTranslationNotFound) {
+		c.String(http.StatusNotFound, "no translation for language "+lang)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to delete %s translation for song ID %d: %v", lang, id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	log.Printf("INFO: Deleted %s translation for song ID %d", lang, id)
+	c.JSON(http.StatusOK, map[string]interface{}{fmt.Sprintf("id #%d", id): lang + " translation deleted"})
+}
+
+// parseLanguage приводит код языка BCP 47 к канонической записи (RU -> ru, pt-br -> pt-BR) и отвечает 400, если код недопустим
+func parseLanguage(c *gin.Context, value string) (string, bool) {
+	tag, err := language.Parse(value)
+	if err != nil || tag == language.Und {
+		log.Printf("ERROR: Invalid language code %q", value)
+		c.String(http.StatusBadRequest, "invalid language code")
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// findTranslation загружает перевод песни и отвечает 404, если его нет
+func (sc *SongController) findTranslation(c *gin.Context, id uint, lang string) (*models.Translation, bool) {
+	translation, err := sc.Store.GetTranslation(id, lang)
+	if errors.Is(err, repository.ErrTranslationNotFound) {
+		c.String(http.StatusNotFound, "no translation for language "+lang)
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to retrieve %s translation for song ID %d: %v", lang, id, err)
+		c.String(http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	return translation, true
+}
+
+// alignedItems сопоставляет части перевода с частями оригинала по номеру и представляет пары в запрошенном формате.
+// Тип и метка части берутся из оригинала, а если у части перевода нет пары — из перевода.
+// Для format=lines строки сопоставляются по номеру внутри части.
+func alignedItems(original, translated models.LyricSections, types []models.SectionType, format string) []interface{} {
+	count := max(len(original), len(translated))
+	items := make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		var source, target *models.LyricSection
+		if i < len(original) {
+			source = &original[i]
+		}
+		if i < len(translated) {
+			target = &translated[i]
+		}
+		head := source
+		if head == nil {
+			head = target
+		}
+		if len(types) > 0 && !slices.Contains(types, head.Type) {
+			continue
+		}
+		verse := models.AlignedVerse{Index: i + 1, Type: head.Type, Label: head.Label}
+		switch format {
+		case "sections":
+			if source != nil {
+				verse.Original = source
+			}
+			if target != nil {
+				verse.Translation = target
+			}
+			items = append(items, verse)
+		case "lines":
+			for j := 0; j < max(sectionLen(source), sectionLen(target)); j++ {
+				line := verse
+				line.Line = j + 1
+				if j < sectionLen(source) {
+					line.Original = source.Lines[j]
+				}
+				if j < sectionLen(target) {
+					line.Translation = target.Lines[j]
+				}
+				items = append(items, line)
+			}
+		default:
+			if source != nil {
+				verse.Original = source.Text()
+			}
+			if target != nil {
+				verse.Translation = target.Text()
+			}
+			items = append(items, verse)
+		}
+	}
+	return items
+}
+
+func sectionLen(section *models.LyricSection) int {
+	if section == nil {
+		return 0
+	}
+	return len(section.Lines)
+}
@@ -0,0 +1,81 @@
+// Package credits выделяет приглашённых исполнителей (featuring) из названий групп и песен:
+// "Ania feat. Bob", "Gamma (ft. Carl & Dan)".
+package credits
+
+import (
+	"go-tunes/models"
+	"regexp"
+	"strings"
+)
+
+// featMarker находит обозначение участия: "feat.", "ft." или "featuring" после пробела или открывающей скобки
+var featMarker = regexp.MustCompile(`(?i)(\s*[(\[]\s*|\s+)(?:feat\.?|ft\.?|featuring)\s+`)
+
+// nameSeparator разделяет несколько приглашённых исполнителей: "Bob, Carl & Dan"
+var nameSeparator = regexp.MustCompile(`\s*(?:,|&)\s*`)
+
+// Featuring — названия группы и песни без приглашённых исполнителей и сами приглашённые исполнители
+type Featuring struct {
+	Group    string
+	Song     string
+	Featured []string
+}
+
+// Parse выделяет приглашённых исполнителей из названий группы и песни. Названия разделяются только после
+// "feat.", "ft." или "featuring": запятая и "&" без этих обозначений — часть названия ("Earth, Wind & Fire",
+// "Simon & Garfunkel"). Повторы и совпадающие с группой имена из списка приглашённых исключаются.
+func Parse(group, song string) Featuring {
+	result := Featuring{}
+	var groupFeatured, songFeatured []string
+	result.Group, groupFeatured = SplitFeatured(group)
+	result.Song, songFeatured = SplitFeatured(song)
+
+	seen := map[string]bool{models.ArtistKey(result.Group): true}
+	for _, name := range append(groupFeatured, songFeatured...) {
+		if key := models.ArtistKey(name); !seen[key] {
+			seen[key] = true
+			result.Featured = append(result.Featured, name)
+		}
+	}
+	return result
+}
+
+// SplitFeatured отделяет от названия приглашённых исполнителей, указанных после "feat.", "ft." или "featuring".
+// Если обозначение стоит в скобках, текст после закрывающей скобки остаётся в названии:
+// "Gamma (feat. Carl) (Remix)" — "Gamma (Remix)" и Carl.
+func SplitFeatured(name string) (string, []string) {
+	name = strings.TrimSpace(name)
+	loc := featMarker.FindStringSubmatchIndex(name)
+	if loc == nil {
+		return name, nil
+	}
+	head := strings.TrimSpace(name[:loc[0]])
+	if head == "" {
+		return name, nil
+	}
+	rest, tail := name[loc[1]:], ""
+	if opening := strings.TrimSpace(name[loc[2]:loc[3]]); opening != "" {
+		closing := ")"
+		if opening == "[" {
+			closing = "]"
+		}
+		if end := strings.Index(rest, closing); end >= 0 {
+			rest, tail = rest[:end], strings.TrimSpace(rest[end+1:])
+		}
+	}
+	if tail != "" {
+		head += " " + tail
+	}
+	return head, splitNames(rest)
+}
+
+// splitNames разбивает перечисление исполнителей на отдельные имена
+func splitNames(list string) []string {
+	var names []string
+	for _, name := range nameSeparator.Split(list, -1) {
+		if name = strings.Trim(name, " \t()[]"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,49 @@
+package credits
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSplitFeatured(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantName     string
+		wantFeatured []string
+	}{
+		{"Uprising", "Uprising", nil},
+		{"Ania feat. Bob", "Ania", []string{"Bob"}},
+		{"Ania ft Bob, Carl & Dan", "Ania", []string{"Bob", "Carl", "Dan"}},
+		{"Gamma (ft. Carl)", "Gamma", []string{"Carl"}},
+		{"Gamma [Featuring Carl] (Remix)", "Gamma (Remix)", []string{"Carl"}},
+		{"feat. Bob", "feat. Bob", nil},
+		{"Left Feet", "Left Feet", nil},
+	}
+	for _, tt := range tests {
+		name, featured := SplitFeatured(tt.name)
+		if name != tt.wantName || !slices.Equal(featured, tt.wantFeatured) {
+			t.Errorf("SplitFeatured(%q) = %q, %q; want %q, %q", tt.name, name, featured, tt.wantName, tt.wantFeatured)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		group, song string
+		want        Featuring
+	}{
+		{"Muse", "Uprising", Featuring{Group: "Muse", Song: "Uprising"}},
+		{"Ania feat. Bob", "Song (ft. Carl)", Featuring{Group: "Ania", Song: "Song", Featured: []string{"Bob", "Carl"}}},
+		{"Ania & Bob", "Song", Featuring{Group: "Ania & Bob", Song: "Song"}},
+		{"Simon & Garfunkel", "The Boxer", Featuring{Group: "Simon & Garfunkel", Song: "The Boxer"}},
+		{"Earth, Wind & Fire", "September", Featuring{Group: "Earth, Wind & Fire", Song: "September"}},
+		{"Earth, Wind & Fire feat. The Emotions", "Boogie Wonderland", Featuring{Group: "Earth, Wind & Fire", Song: "Boogie Wonderland", Featured: []string{"The Emotions"}}},
+		{"Ania feat. Bob", "Song feat. bob & ANIA", Featuring{Group: "Ania", Song: "Song", Featured: []string{"Bob"}}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.group, tt.song); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.group, tt.song, got, tt.want)
+		}
+	}
+}
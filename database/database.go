@@ -18,7 +18,8 @@ func Connect() *gorm.DB {
     once.Do(func() {
         dsn := os.Getenv("DATABASE_URL")
         var err error
-        db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+        // TranslateError приводит ошибки драйвера к ошибкам GORM (например, gorm.ErrDuplicatedKey)
+        db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
         if err != nil {
             log.Fatal("Failed to connect to the database:", err)
         }
@@ -3,12 +3,15 @@ package database
 import (
     "log"
     "gorm.io/gorm"
-    "go-tunes/models"
 )
 
+// Migrate применяет все ещё не применённые миграции из database/scripts
 func Migrate(db *gorm.DB) {
-    err := db.AutoMigrate(&models.Song{})
+    migrator, err := NewMigrator(db)
     if err != nil {
+        log.Fatal("Failed to load migrations: ", err)
+    }
+    if err := migrator.Up(); err != nil {
         log.Fatal("Migration failed: ", err)
     }
 }
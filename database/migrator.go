@@ -0,0 +1,339 @@
+package database
+
+import (
+    "embed"
+    "errors"
+    "fmt"
+    "io/fs"
+    "log"
+    "path"
+    "regexp"
+    "sort"
+    "strconv"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+//go:embed scripts/*.sql
+var scriptsFS embed.FS
+
+// legacyBaselineVersion — версия, которой отмечается схема, созданная до появления мигратора вручную скриптами
+// 000001-000002 или AutoMigrate. Скрипты 000003-000005 выполняются для такой схемы заново: они учитывают
+// уже созданные AutoMigrate колонки и индексы, а удаление дубликатов и уникальный индекс по паре группа+песня
+// нужны обеим схемам.
+const legacyBaselineVersion = 2
+
+// ErrDirty возвращается, если предыдущая миграция завершилась с ошибкой и состояние схемы не определено
+var ErrDirty = errors.New("database is in a dirty migration state, fix it manually and run `migrate force <version>`")
+
+var scriptName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration описывает одну версию схемы с SQL-скриптами применения и отката
+type Migration struct {
+    Version uint
+    Name    string
+    Up      string
+    Down    string
+}
+
+// MigrationStatus описывает состояние миграции в базе данных
+type MigrationStatus struct {
+    Version   uint
+    Name      string
+    Applied   bool
+    Dirty     bool
+    AppliedAt *time.Time
+}
+
+// schemaMigration — запись таблицы schema_migrations
+type schemaMigration struct {
+    Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+    Name      string    `gorm:"not null"`
+    Dirty     bool      `gorm:"not null;default:false"`
+    AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+    return "schema_migrations"
+}
+
+// Migrator применяет и откатывает пронумерованные скрипты из database/scripts,
+// сохраняя применённые версии в таблице schema_migrations
+type Migrator struct {
+    db         *gorm.DB
+    migrations []Migration
+}
+
+// NewMigrator загружает встроенные в бинарный файл скрипты миграций
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+    migrations, err := loadMigrations(scriptsFS, "scripts")
+    if err != nil {
+        return nil, err
+    }
+    return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations читает скрипты вида 000001_name.up.sql / 000001_name.down.sql, упорядочивая их по версии
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+    entries, err := fs.ReadDir(fsys, dir)
+    if err != nil {
+        return nil, fmt.Errorf("could not read migration scripts: %w", err)
+    }
+
+    byVersion := make(map[uint]*Migration)
+    for _, entry := range entries {
+        match := scriptName.FindStringSubmatch(entry.Name())
+        if entry.IsDir() || match == nil {
+            continue
+        }
+        version, err := strconv.ParseUint(match[1], 10, 0)
+        if err != nil {
+            return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+        }
+        content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+        if err != nil {
+            return nil, fmt.Errorf("could not read migration script %s: %w", entry.Name(), err)
+        }
+
+        migration, ok := byVersion[uint(version)]
+        if !ok {
+            migration = &Migration{Version: uint(version), Name: match[2]}
+            byVersion[uint(version)] = migration
+        } else if migration.Name != match[2] {
+            return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+        }
+        if match[3] == "up" {
+            migration.Up = string(content)
+        } else {
+            migration.Down = string(content)
+        }
+    }
+
+    migrations := make([]Migration, 0, len(byVersion))
+    for _, migration := range byVersion {
+        if migration.Up == "" {
+            return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+        }
+        migrations = append(migrations, *migration)
+    }
+    sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+    return migrations, nil
+}
+
+// Up применяет все ещё не применённые миграции по порядку
+func (m *Migrator) Up() error {
+    applied, err := m.prepare()
+    if err != nil {
+        return err
+    }
+
+    count := 0
+    for _, migration := range m.migrations {
+        if _, ok := applied[migration.Version]; ok {
+            continue
+        }
+        if err := m.apply(migration); err != nil {
+            return err
+        }
+        count++
+    }
+    log.Printf("INFO: Applied %d migrations", count)
+    return nil
+}
+
+// Down откатывает применённые миграции с версией больше target, начиная с последней
+func (m *Migrator) Down(target uint) error {
+    applied, err := m.prepare()
+    if err != nil {
+        return err
+    }
+
+    count := 0
+    for i := len(m.migrations) - 1; i >= 0; i-- {
+        migration := m.migrations[i]
+        if migration.Version <= target {
+            break
+        }
+        if _, ok := applied[migration.Version]; !ok {
+            continue
+        }
+        if err := m.rollback(migration); err != nil {
+            return err
+        }
+        count++
+    }
+    log.Printf("INFO: Rolled back %d migrations, current version: %d", count, target)
+    return nil
+}
+
+// Version возвращает последнюю применённую версию схемы (0, если миграций нет)
+func (m *Migrator) Version() (uint, error) {
+    applied, err := m.prepare()
+    if err != nil {
+        return 0, err
+    }
+    var version uint
+    for v := range applied {
+        if v > version {
+            version = v
+        }
+    }
+    return version, nil
+}
+
+// Status возвращает состояние каждой известной миграции
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+    if err := m.ensureTable(); err != nil {
+        return nil, err
+    }
+    applied, err := m.appliedMigrations()
+    if err != nil {
+        return nil, err
+    }
+
+    statuses := make([]MigrationStatus, 0, len(m.migrations))
+    for _, migration := range m.migrations {
+        status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+        if record, ok := applied[migration.Version]; ok {
+            appliedAt := record.AppliedAt
+            status.Applied = !record.Dirty
+            status.Dirty = record.Dirty
+            status.AppliedAt = &appliedAt
+        }
+        statuses = append(statuses, status)
+    }
+    return statuses, nil
+}
+
+// Force помечает схему как находящуюся в версии version без выполнения скриптов и снимает признак dirty.
+// Используется после ручного исправления схемы.
+func (m *Migrator) Force(version uint) error {
+    if err := m.ensureTable(); err != nil {
+        return err
+    }
+    return m.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Where("version > ?", version).Delete(&schemaMigration{}).Error; err != nil {
+            return err
+        }
+        if err := tx.Model(&schemaMigration{}).Where("dirty").Update("dirty", false).Error; err != nil {
+            return err
+        }
+        for _, migration := range m.migrations {
+            if migration.Version > version {
+                break
+            }
+            record := schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+            if err := tx.Where(schemaMigration{Version: migration.Version}).FirstOrCreate(&record).Error; err != nil {
+                return err
+            }
+        }
+        log.Printf("INFO: Forced migration version %d", version)
+        return nil
+    })
+}
+
+// prepare создаёт служебную таблицу, проверяет отсутствие dirty-состояния и возвращает применённые версии
+func (m *Migrator) prepare() (map[uint]schemaMigration, error) {
+    if err := m.ensureTable(); err != nil {
+        return nil, err
+    }
+    applied, err := m.appliedMigrations()
+    if err != nil {
+        return nil, err
+    }
+    for _, record := range applied {
+        if record.Dirty {
+            return nil, fmt.Errorf("migration %d_%s: %w", record.Version, record.Name, ErrDirty)
+        }
+    }
+    return applied, nil
+}
+
+// ensureTable создаёт таблицу schema_migrations. Если её нет, а таблица songs уже существует,
+// схема была создана до появления мигратора и отмечается версией legacyBaselineVersion без выполнения скриптов.
+func (m *Migrator) ensureTable() error {
+    migrator := m.db.Migrator()
+    if migrator.HasTable(&schemaMigration{}) {
+        return nil
+    }
+
+    baseline := baselineVersion(migrator)
+    if err := migrator.CreateTable(&schemaMigration{}); err != nil {
+        return fmt.Errorf("could not create schema_migrations table: %w", err)
+    }
+    if baseline > 0 {
+        log.Printf("INFO: Existing schema detected, marking it as migration version %d", baseline)
+        return m.Force(baseline)
+    }
+    return nil
+}
+
+// schemaInspector — методы gorm.Migrator, по которым распознаётся схема, созданная до появления мигратора
+type schemaInspector interface {
+    HasTable(dst interface{}) bool
+}
+
+// baselineVersion возвращает версию существующей схемы без таблицы schema_migrations (0 — схемы нет)
+func baselineVersion(schema schemaInspector) uint {
+    if schema.HasTable("songs") {
+        return legacyBaselineVersion
+    }
+    return 0
+}
+
+func (m *Migrator) appliedMigrations() (map[uint]schemaMigration, error) {
+    var records []schemaMigration
+    if err := m.db.Find(&records).Error; err != nil {
+        return nil, fmt.Errorf("could not read schema_migrations: %w", err)
+    }
+    applied := make(map[uint]schemaMigration, len(records))
+    for _, record := range records {
+        applied[record.Version] = record
+    }
+    return applied, nil
+}
+
+// apply выполняет up-скрипт в транзакции. Версия помечается dirty до выполнения скрипта
+// и остаётся такой, если скрипт завершился с ошибкой.
+func (m *Migrator) apply(migration Migration) error {
+    log.Printf("INFO: Applying migration %d_%s", migration.Version, migration.Name)
+    record := schemaMigration{Version: migration.Version, Name: migration.Name, Dirty: true, AppliedAt: time.Now()}
+    if err := m.db.Create(&record).Error; err != nil {
+        return fmt.Errorf("could not record migration %d: %w", migration.Version, err)
+    }
+
+    err := m.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec(migration.Up).Error; err != nil {
+            return err
+        }
+        return tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Update("dirty", false).Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Migration %d_%s failed: %v", migration.Version, migration.Name, err)
+        return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+    }
+    return nil
+}
+
+// rollback выполняет down-скрипт в транзакции и удаляет запись о версии
+func (m *Migrator) rollback(migration Migration) error {
+    log.Printf("INFO: Rolling back migration %d_%s", migration.Version, migration.Name)
+    if err := m.db.Model(&schemaMigration{}).Where("version = ?", migration.Version).Update("dirty", true).Error; err != nil {
+        return fmt.Errorf("could not mark migration %d as dirty: %w", migration.Version, err)
+    }
+
+    err := m.db.Transaction(func(tx *gorm.DB) error {
+        if migration.Down != "" {
+            if err := tx.Exec(migration.Down).Error; err != nil {
+                return err
+            }
+        }
+        return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Rollback of migration %d_%s failed: %v", migration.Version, migration.Name, err)
+        return fmt.Errorf("rollback of migration %d_%s failed: %w", migration.Version, migration.Name, err)
+    }
+    return nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+    "regexp"
+    "strings"
+    "testing"
+    "testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+    fsys := fstest.MapFS{
+        "scripts/000002_songs.up.sql":   {Data: []byte("CREATE TABLE songs ();")},
+        "scripts/000002_songs.down.sql": {Data: []byte("DROP TABLE songs;")},
+        "scripts/000010_keys.up.sql":    {Data: []byte("ALTER TABLE songs ADD key text;")},
+        "scripts/000001_init.up.sql":    {Data: []byte("CREATE SCHEMA music;")},
+        "scripts/README.md":             {Data: []byte("notes")},
+        "scripts/000003_draft.sql":      {Data: []byte("SELECT 1;")},
+    }
+
+    migrations, err := loadMigrations(fsys, "scripts")
+    if err != nil {
+        t.Fatalf("loadMigrations() error = %v", err)
+    }
+    want := []Migration{
+        {Version: 1, Name: "init", Up: "CREATE SCHEMA music;"},
+        {Version: 2, Name: "songs", Up: "CREATE TABLE songs ();", Down: "DROP TABLE songs;"},
+        {Version: 10, Name: "keys", Up: "ALTER TABLE songs ADD key text;"},
+    }
+    if len(migrations) != len(want) {
+        t.Fatalf("loadMigrations() = %+v, want %+v", migrations, want)
+    }
+    for i := range want {
+        if migrations[i] != want[i] {
+            t.Errorf("migrations[%d] = %+v, want %+v", i, migrations[i], want[i])
+        }
+    }
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+    tests := []struct {
+        name    string
+        fsys    fstest.MapFS
+        wantErr string
+    }{
+        {
+            name: "conflicting names",
+            fsys: fstest.MapFS{
+                "scripts/000001_init.up.sql":      {Data: []byte("SELECT 1;")},
+                "scripts/000001_initial.down.sql": {Data: []byte("SELECT 1;")},
+            },
+            wantErr: "conflicting names",
+        },
+        {
+            name:    "missing up script",
+            fsys:    fstest.MapFS{"scripts/000001_init.down.sql": {Data: []byte("SELECT 1;")}},
+            wantErr: "has no up script",
+        },
+        {
+            name:    "missing directory",
+            fsys:    fstest.MapFS{},
+            wantErr: "could not read migration scripts",
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, err := loadMigrations(tt.fsys, "scripts")
+            if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+                t.Errorf("loadMigrations() error = %v, want it to contain %q", err, tt.wantErr)
+            }
+        })
+    }
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+    migrations, err := loadMigrations(scriptsFS, "scripts")
+    if err != nil {
+        t.Fatalf("loadMigrations() error = %v", err)
+    }
+    if len(migrations) < 5 {
+        t.Fatalf("loaded %d migrations, want at least the 5 scripts of the legacy schema", len(migrations))
+    }
+    for i, migration := range migrations {
+        if migration.Version != uint(i+1) {
+            t.Errorf("migration %d_%s has version %d, want %d", migration.Version, migration.Name, migration.Version, i+1)
+        }
+        if strings.TrimSpace(migration.Down) == "" {
+            t.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+        }
+    }
+}
+
+// fakeSchema — набор таблиц существующей базы данных
+type fakeSchema map[string]bool
+
+func (s fakeSchema) HasTable(dst interface{}) bool {
+    name, _ := dst.(string)
+    return s[name]
+}
+
+func TestBaselineVersion(t *testing.T) {
+    tests := []struct {
+        name   string
+        schema fakeSchema
+        want   uint
+    }{
+        {"empty database", fakeSchema{}, 0},
+        {"schema created by scripts or AutoMigrate", fakeSchema{"songs": true}, legacyBaselineVersion},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := baselineVersion(tt.schema); got != tt.want {
+                t.Errorf("baselineVersion() = %d, want %d", got, tt.want)
+            }
+        })
+    }
+}
+
+// schemaChange находит создание колонки или индекса в SQL-скрипте
+var schemaChange = regexp.MustCompile(`(?i)(ADD COLUMN|CREATE (UNIQUE )?INDEX)\s+(IF NOT EXISTS\s+)?`)
+
+// TestLegacySchemaMigrations проверяет, что скрипты, которые выполняются заново для схемы, созданной AutoMigrate
+// (в ней уже есть created_at, updated_at, deleted_at и индекс idx_songs_deleted_at, а release_date — строка),
+// не падают на уже существующих колонках и индексах и приводят её к схеме мигратора
+func TestLegacySchemaMigrations(t *testing.T) {
+    migrations, err := loadMigrations(scriptsFS, "scripts")
+    if err != nil {
+        t.Fatalf("loadMigrations() error = %v", err)
+    }
+
+    var up strings.Builder
+    for _, migration := range migrations {
+        if migration.Version <= legacyBaselineVersion || migration.Version > 5 {
+            continue
+        }
+        up.WriteString(migration.Up)
+        for _, match := range schemaChange.FindAllStringSubmatch(migration.Up, -1) {
+            if match[3] == "" {
+                t.Errorf("migration %d_%s: %q without IF NOT EXISTS fails on the AutoMigrate schema", migration.Version, migration.Name, strings.TrimSpace(match[0]))
+            }
+        }
+    }
+
+    script := up.String()
+    for _, want := range []string{
+        "CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_group_song_active",
+        "AND a.deleted_at IS NULL AND b.deleted_at IS NULL",
+        "data_type = 'date'",
+    } {
+        if !strings.Contains(script, want) {
+            t.Errorf("migrations after version %d do not contain %q", legacyBaselineVersion, want)
+        }
+    }
+}
@@ -1 +1,2 @@
-DROP DATABASE IF EXISTS musicdb;
\ No newline at end of file
+-- База данных musicdb удаляется вручную после отката всех миграций:
+--   DROP DATABASE IF EXISTS musicdb;
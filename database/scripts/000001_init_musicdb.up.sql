@@ -1,2 +1,3 @@
--- Создаем базу данных musicdb
-CREATE DATABASE musicdb;
\ No newline at end of file
+-- База данных musicdb создаётся до запуска миграций, так как мигратор подключается к ней по DATABASE_URL:
+--   CREATE DATABASE musicdb;
+-- Миграция оставлена, чтобы сохранить нумерацию версий.
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_songs_group_song;
+CREATE INDEX idx_group_song ON songs ("group", song);
@@ -0,0 +1,19 @@
+-- Удаляем дубликаты пары группа+песня, оставляя самую раннюю запись, и заменяем неуникальный индекс уникальным,
+-- чтобы пара группа+песня встречалась ровно один раз.
+-- В схеме, созданной AutoMigrate до появления мигратора, уже есть колонка deleted_at: дубликаты ищутся только
+-- среди неудалённых песен, а уникальный индекс по неудалённым песням создаёт миграция 000004.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'songs' AND column_name = 'deleted_at') THEN
+        DELETE FROM songs a USING songs b
+        WHERE a.id > b.id AND a."group" = b."group" AND a.song = b.song
+          AND a.deleted_at IS NULL AND b.deleted_at IS NULL;
+    ELSE
+        DELETE FROM songs a USING songs b
+        WHERE a.id > b.id AND a."group" = b."group" AND a.song = b.song;
+        CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_group_song ON songs ("group", song);
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_group_song;
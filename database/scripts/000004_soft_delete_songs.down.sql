@@ -0,0 +1,8 @@
+-- Песни из корзины удаляются окончательно, иначе уникальный индекс по всем песням может не создаться
+DELETE FROM songs WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_songs_group_song_active;
+CREATE UNIQUE INDEX idx_songs_group_song ON songs ("group", song);
+
+DROP INDEX IF EXISTS idx_songs_deleted_at;
+ALTER TABLE songs DROP COLUMN IF EXISTS deleted_at;
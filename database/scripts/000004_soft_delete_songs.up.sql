@@ -0,0 +1,7 @@
+-- Колонка для мягкого удаления песен (корзина)
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_songs_deleted_at ON songs (deleted_at);
+
+-- Пара группа+песня уникальна только среди неудалённых песен, чтобы удалённую песню можно было добавить заново
+DROP INDEX IF EXISTS idx_songs_group_song;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_group_song_active ON songs ("group", song) WHERE deleted_at IS NULL;
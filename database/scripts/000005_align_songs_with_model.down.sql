@@ -0,0 +1,6 @@
+-- Даты, не соответствующие формату DD.MM.YYYY, при откате теряются
+ALTER TABLE songs ALTER COLUMN release_date TYPE DATE USING
+    CASE WHEN release_date ~ '^\d{2}\.\d{2}\.\d{4}$' THEN to_date(release_date, 'DD.MM.YYYY') END;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE songs DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,16 @@
+-- Служебные даты создания и изменения песни
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- Дата релиза приходит из внешнего API в произвольном формате (например, 16.07.2006) и хранится строкой.
+-- В схеме, созданной AutoMigrate, она уже строка и переводится в VARCHAR без преобразования.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'songs' AND column_name = 'release_date'
+                 AND data_type = 'date') THEN
+        ALTER TABLE songs ALTER COLUMN release_date TYPE VARCHAR(255) USING to_char(release_date, 'DD.MM.YYYY');
+    ELSE
+        ALTER TABLE songs ALTER COLUMN release_date TYPE VARCHAR(255);
+    END IF;
+END $$;
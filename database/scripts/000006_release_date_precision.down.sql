@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_songs_release_date;
+
+ALTER TABLE songs ALTER COLUMN release_date TYPE VARCHAR(255) USING CASE release_date_precision
+    WHEN 'year' THEN to_char(release_date, 'YYYY')
+    WHEN 'month' THEN to_char(release_date, 'MM.YYYY')
+    ELSE to_char(release_date, 'DD.MM.YYYY')
+END;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS release_date_precision;
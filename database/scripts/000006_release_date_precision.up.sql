@@ -0,0 +1,19 @@
+-- Точность даты релиза: day, month или year. Неточные даты хранятся как начало периода.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS release_date_precision VARCHAR(5);
+
+UPDATE songs SET release_date_precision = CASE
+    WHEN release_date ~ '^\d{4}-\d{2}-\d{2}' OR release_date ~ '^\d{1,2}\.\d{1,2}\.\d{4}$' THEN 'day'
+    WHEN release_date ~ '^\d{4}-\d{2}$' OR release_date ~ '^\d{1,2}\.\d{4}$' THEN 'month'
+    WHEN release_date ~ '^\d{4}$' THEN 'year'
+END;
+
+-- Строковые даты в форматах DD.MM.YYYY, YYYY-MM-DD, YYYY-MM, MM.YYYY и YYYY переводятся в DATE, остальные сбрасываются
+ALTER TABLE songs ALTER COLUMN release_date TYPE DATE USING CASE
+    WHEN release_date ~ '^\d{4}-\d{2}-\d{2}' THEN to_date(substr(release_date, 1, 10), 'YYYY-MM-DD')
+    WHEN release_date ~ '^\d{1,2}\.\d{1,2}\.\d{4}$' THEN to_date(release_date, 'DD.MM.YYYY')
+    WHEN release_date ~ '^\d{4}-\d{2}$' THEN to_date(release_date, 'YYYY-MM')
+    WHEN release_date ~ '^\d{1,2}\.\d{4}$' THEN to_date(release_date, 'MM.YYYY')
+    WHEN release_date ~ '^\d{4}$' THEN to_date(release_date, 'YYYY')
+END;
+
+CREATE INDEX IF NOT EXISTS idx_songs_release_date ON songs (release_date);
@@ -0,0 +1,9 @@
+-- Полнотекстовый индекс песен. Конфигурация russian применяет русский стеммер к кириллице
+-- и английский стеммер к латинице. Название группы и песни весят больше текста.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce("group", '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(song, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(text, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_songs_search_vector ON songs USING GIN (search_vector);
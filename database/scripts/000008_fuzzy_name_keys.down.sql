@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_songs_name_key_translit_trgm;
+DROP INDEX IF EXISTS idx_songs_song_key_trgm;
+DROP INDEX IF EXISTS idx_songs_group_key_trgm;
+ALTER TABLE songs DROP COLUMN IF EXISTS song_key;
+ALTER TABLE songs DROP COLUMN IF EXISTS group_key;
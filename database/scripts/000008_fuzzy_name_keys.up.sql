@@ -0,0 +1,11 @@
+-- Ключи поиска похожих названий с учётом транслитерации (нижний регистр, без пунктуации, латиница)
+-- вычисляются приложением (пакет translit). Для существующих песен они заполняются при запуске сервиса,
+-- пока остаются NULL. Триграммные индексы ускоряют поиск похожих названий.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS group_key TEXT;
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS song_key TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_songs_group_key_trgm ON songs USING GIN (group_key gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_song_key_trgm ON songs USING GIN (song_key gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_name_key_translit_trgm ON songs USING GIN ((group_key || ' ' || song_key) gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- Текст песни, разобранный на части (куплеты, припевы и т. п.). Вычисляется приложением (models.ParseSections);
+-- для существующих песен заполняется при запуске сервиса, пока остаётся NULL.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS sections JSONB;
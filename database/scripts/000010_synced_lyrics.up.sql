@@ -0,0 +1,2 @@
+-- Текст песни с синхронизацией по времени в формате LRC (необязательный)
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS synced_lyrics TEXT;
@@ -0,0 +1,2 @@
+-- Аккордовый лист в формате ChordPro (необязательный)
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS chordpro TEXT;
@@ -0,0 +1,15 @@
+-- Переводы текстов песен: не больше одного перевода песни на каждый язык
+CREATE TABLE IF NOT EXISTS song_translations (
+    id SERIAL PRIMARY KEY,                          -- Уникальный идентификатор перевода
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,                  -- Код языка BCP 47: ru, en, pt-BR
+    text TEXT NOT NULL,                             -- Текст перевода
+    sections JSONB,                                 -- Текст перевода, разобранный на части (models.ParseSections)
+    source VARCHAR(16) NOT NULL,                    -- Происхождение: official, community, machine
+    translator VARCHAR(255),                        -- Автор перевода
+    source_url VARCHAR(2083)                        -- Ссылка на источник перевода
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_song_translations_song_language ON song_translations (song_id, language);
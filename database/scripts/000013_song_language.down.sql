@@ -0,0 +1,15 @@
+-- Полнотекстовый индекс снова строится в конфигурации russian (миграция 000007)
+DROP INDEX IF EXISTS idx_songs_search_vector;
+ALTER TABLE songs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE songs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce("group", '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(song, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(text, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_songs_search_vector ON songs USING GIN (search_vector);
+
+DROP FUNCTION IF EXISTS song_search_query(TEXT);
+DROP FUNCTION IF EXISTS song_search_config(VARCHAR);
+
+DROP INDEX IF EXISTS idx_songs_language;
+ALTER TABLE songs DROP COLUMN IF EXISTS language;
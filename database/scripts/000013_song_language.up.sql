@@ -0,0 +1,38 @@
+-- Язык текста песни (код ISO 639-1). Определяется приложением (пакет langdetect);
+-- для существующих песен заполняется при запуске сервиса, пока остаётся NULL.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS language VARCHAR(35);
+CREATE INDEX IF NOT EXISTS idx_songs_language ON songs (language);
+
+-- Конфигурация полнотекстового поиска для языка текста: стеммер языка, если он есть в PostgreSQL,
+-- иначе (в том числе для песен с неопределённым языком) simple — слова без стемминга
+CREATE OR REPLACE FUNCTION song_search_config(language VARCHAR) RETURNS regconfig AS $$
+    SELECT CASE language
+        WHEN 'ru' THEN 'russian'
+        WHEN 'en' THEN 'english'
+        WHEN 'de' THEN 'german'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'it' THEN 'italian'
+        WHEN 'pt' THEN 'portuguese'
+        ELSE 'simple'
+    END::regconfig
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Запрос во всех конфигурациях song_search_config: по нему GIN-индекс отбирает кандидатов,
+-- которые затем проверяются запросом в конфигурации языка песни
+CREATE OR REPLACE FUNCTION song_search_query(query TEXT) RETURNS tsquery AS $$
+    SELECT websearch_to_tsquery('russian', query) || websearch_to_tsquery('english', query) ||
+        websearch_to_tsquery('german', query) || websearch_to_tsquery('spanish', query) ||
+        websearch_to_tsquery('french', query) || websearch_to_tsquery('italian', query) ||
+        websearch_to_tsquery('portuguese', query) || websearch_to_tsquery('simple', query)
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Полнотекстовый индекс строится в конфигурации языка песни вместо russian для всех песен
+DROP INDEX IF EXISTS idx_songs_search_vector;
+ALTER TABLE songs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE songs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(song_search_config(language), coalesce("group", '')), 'A') ||
+    setweight(to_tsvector(song_search_config(language), coalesce(song, '')), 'A') ||
+    setweight(to_tsvector(song_search_config(language), coalesce(text, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_songs_search_vector ON songs USING GIN (search_vector);
@@ -0,0 +1,34 @@
+-- Исполнители: каноническое название, псевдонимы, страна и год основания
+CREATE TABLE IF NOT EXISTS artists (
+    id SERIAL PRIMARY KEY,                          -- Уникальный идентификатор исполнителя
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    name VARCHAR(255) NOT NULL,                     -- Каноническое название
+    name_key VARCHAR(255),                          -- Ключ названия без учёта регистра и транслитерации (models.ArtistKey)
+    aliases JSONB NOT NULL DEFAULT '[]',            -- Другие названия исполнителя
+    alias_keys JSONB NOT NULL DEFAULT '[]',         -- Ключи псевдонимов
+    country VARCHAR(2),                             -- Код страны ISO 3166-1 alpha-2
+    formed_year INTEGER                             -- Год основания
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_name_key ON artists (name_key);
+CREATE INDEX IF NOT EXISTS idx_artists_alias_keys ON artists USING GIN (alias_keys);
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS artist_id INTEGER REFERENCES artists (id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_songs_artist_id ON songs (artist_id);
+
+-- Названия групп существующих песен становятся исполнителями: написания, различающиеся только регистром
+-- и пробелами по краям, объединяются, каноническим становится самое частое написание.
+-- Ключи названий заполняются при запуске сервиса; тогда же объединяются названия, различающиеся
+-- транслитерацией или пунктуацией (SongRepository.BackfillArtists).
+-- Названия групп песен не меняются: переименование в каноническое название исполнителя выполняется
+-- при запуске сервиса только с ARTIST_RENAME_GROUPS=true.
+INSERT INTO artists (name)
+SELECT DISTINCT ON (lower(btrim("group"))) btrim("group")
+FROM songs
+GROUP BY lower(btrim("group")), btrim("group")
+ORDER BY lower(btrim("group")), count(*) DESC, btrim("group");
+
+UPDATE songs SET artist_id = artists.id
+FROM artists
+WHERE lower(btrim(songs."group")) = lower(artists.name);
@@ -0,0 +1,23 @@
+-- Альбомы, EP, синглы и сборники
+CREATE TABLE IF NOT EXISTS albums (
+    id SERIAL PRIMARY KEY,                          -- Уникальный идентификатор альбома
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    title VARCHAR(255) NOT NULL,                    -- Название
+    artist_id INTEGER REFERENCES artists (id) ON DELETE RESTRICT, -- Исполнитель; у сборников может отсутствовать
+    release_date DATE,                              -- Дата релиза (начало периода для неточных дат)
+    release_date_precision VARCHAR(5),              -- Точность даты: day, month, year
+    type VARCHAR(16) NOT NULL                       -- Тип: album, ep, single, compilation
+);
+
+CREATE INDEX IF NOT EXISTS idx_albums_artist_id ON albums (artist_id);
+
+-- Композиции альбома: песня входит в альбом не больше одного раза, номера композиций начинаются с 1
+CREATE TABLE IF NOT EXISTS album_tracks (
+    album_id INTEGER NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,                      -- Номер композиции
+    PRIMARY KEY (album_id, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_tracks_song_id ON album_tracks (song_id);
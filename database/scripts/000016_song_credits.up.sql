@@ -0,0 +1,11 @@
+-- Участники песен: авторы музыки и текста, продюсеры и приглашённые исполнители.
+-- Участник — исполнитель из таблицы artists; один исполнитель может участвовать в песне в нескольких ролях.
+CREATE TABLE IF NOT EXISTS song_credits (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    artist_id INTEGER NOT NULL REFERENCES artists (id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL,                      -- Роль: composer, lyricist, producer, featured
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (song_id, artist_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_song_credits_artist_id ON song_credits (artist_id);
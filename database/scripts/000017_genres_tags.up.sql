@@ -0,0 +1,30 @@
+-- Дерево жанров: у жанра может быть родительский жанр (Rock > Alternative Rock)
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,                          -- Уникальный идентификатор жанра
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    name VARCHAR(255) NOT NULL,                     -- Название
+    name_key VARCHAR(255) NOT NULL,                 -- Ключ названия без учёта регистра и транслитерации
+    parent_id INTEGER REFERENCES genres (id) ON DELETE RESTRICT -- Родительский жанр
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_name_key ON genres (name_key);
+CREATE INDEX IF NOT EXISTS idx_genres_parent_id ON genres (parent_id);
+
+-- Жанры песен
+CREATE TABLE IF NOT EXISTS song_genres (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres (id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, genre_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_song_genres_genre_id ON song_genres (genre_id);
+
+-- Пользовательские теги песен в нормализованной записи (нижний регистр, одиночные пробелы)
+CREATE TABLE IF NOT EXISTS song_tags (
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (song_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_song_tags_tag ON song_tags (tag);
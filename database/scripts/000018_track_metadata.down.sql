@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_songs_bpm;
+DROP INDEX IF EXISTS idx_songs_isrc;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS explicit;
+ALTER TABLE songs DROP COLUMN IF EXISTS mode;
+ALTER TABLE songs DROP COLUMN IF EXISTS musical_key;
+ALTER TABLE songs DROP COLUMN IF EXISTS bpm;
+ALTER TABLE songs DROP COLUMN IF EXISTS isrc;
+ALTER TABLE songs DROP COLUMN IF EXISTS duration_seconds;
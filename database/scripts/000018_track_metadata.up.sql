@@ -0,0 +1,10 @@
+-- Необязательные сведения о записи: приходят из внешнего API обогащения или задаются при изменении песни
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS duration_seconds INTEGER CHECK (duration_seconds > 0); -- Длительность в секундах
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS isrc VARCHAR(12);                                       -- ISRC без дефисов
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS bpm DOUBLE PRECISION CHECK (bpm > 0);                   -- Темп, ударов в минуту
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS musical_key VARCHAR(2);                                 -- Тоника: C, C#, Db, ...
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS mode VARCHAR(5) CHECK (mode IN ('major', 'minor'));     -- Лад
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS explicit BOOLEAN;                                       -- Ненормативная лексика
+
+CREATE INDEX IF NOT EXISTS idx_songs_isrc ON songs (isrc);
+CREATE INDEX IF NOT EXISTS idx_songs_bpm ON songs (bpm);
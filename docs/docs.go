@@ -15,38 +15,62 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/info": {
+        "/albums": {
             "get": {
-                "description": "Retrieve detailed information about a song, add to database if not present",
+                "description": "Retrieve albums without track listings, newest releases first",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get song details",
+                "summary": "Get all albums",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Group",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
+                        "description": "Part of the album title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "artist_id",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "album",
+                            "ep",
+                            "single",
+                            "compilation"
+                        ],
                         "type": "string",
-                        "description": "Song",
-                        "name": "song",
-                        "in": "query",
-                        "required": true
+                        "description": "Release type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page (at most 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SongDetail"
+                            "$ref": "#/definitions/models.AlbumList"
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "invalid filter",
                         "schema": {
                             "type": "string"
                         }
@@ -58,69 +82,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/songs": {
-            "get": {
-                "description": "Retrieve all songs with optional filtering and pagination",
+            },
+            "post": {
+                "description": "Add an album, EP, single or compilation with an ordered track listing of existing songs. Every type except compilation requires an artist.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get all songs",
+                "summary": "Add a new album",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Song",
-                        "name": "song",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release Date",
-                        "name": "release_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Text",
-                        "name": "text",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Link",
-                        "name": "link",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Album",
+                        "name": "album",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AlbumRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Song"
-                            }
+                            "$ref": "#/definitions/models.Album"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input, unknown artist or song",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -132,43 +125,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/songs/{id}": {
-            "put": {
-                "description": "Update an existing song by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/albums/{id}": {
+            "get": {
+                "description": "Retrieve an album with its tracks in order. Songs in the trash are left out of the listing.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update a song",
+                "summary": "Get an album by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated song data",
-                        "name": "song",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Song"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Song"
+                            "$ref": "#/definitions/models.Album"
                         }
                     },
                     "400": {
-                        "description": "invalid input",
+                        "description": "invalid album id",
                         "schema": {
                             "type": "string"
                         }
@@ -178,19 +159,25 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a song by its ID",
+                "description": "Delete an album and its track listing; the songs are kept",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete a song",
+                "summary": "Delete an album",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -204,6 +191,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "invalid album id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "404": {
                         "description": "not found",
                         "schema": {
@@ -219,21 +212,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/songs/{id}/verses": {
-            "get": {
-                "description": "Retrieve the text of a song by its ID with pagination by verses",
+        "/albums/{id}/tracks": {
+            "put": {
+                "description": "Replace the track listing of an album with the given songs in order. Tracks are numbered from 1; songs missing from the list are removed from the album.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a song by ID with pagination",
+                "summary": "Reorder album tracks",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Song IDs in track order",
+                        "name": "tracks",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TracksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Album"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input or unknown song",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/artists": {
+            "get": {
+                "description": "Retrieve artists ordered by name. The name filter matches names and aliases ignoring case and transliteration.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all artists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the artist name or alias",
+                        "name": "name",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -243,8 +291,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Verses per page",
+                        "default": 10,
+                        "description": "Results per page (at most 100)",
                         "name": "limit",
                         "in": "query"
                     }
@@ -253,12 +301,52 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.ArtistList"
                         }
                     },
-                    "404": {
-                        "description": "not found",
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an artist with a canonical name, aliases, country (ISO 3166-1 alpha-2) and formation year. Names are compared ignoring case, punctuation and transliteration; a name that matches an existing artist's name or alias is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add a new artist",
+                "parameters": [
+                    {
+                        "description": "Artist",
+                        "name": "artist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ArtistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "artist already exists",
                         "schema": {
                             "type": "string"
                         }
@@ -271,52 +359,3431 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Song": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "group": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "release_date": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
-                },
-                "text": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
         },
-        "models.SongDetail": {
-            "type": "object",
-            "properties": {
-                "link": {
-                    "type": "string"
-                },
+        "/artists/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get an artist by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an artist by its ID. Renaming the artist renames the group of all its songs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated artist data",
+                        "name": "artist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ArtistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "artist or song already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an artist by its ID. Artists with albums or songs, including songs in the trash, cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "artist has songs or albums",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/artists/{id}/songs": {
+            "get": {
+                "description": "Retrieve the artist's songs with sorting and pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get songs of an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "-release_date",
+                        "description": "Comma-separated sort fields, prefix with - for descending (id, group, song, release_date, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongList"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of the artist's songs"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist id or sort",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/autocomplete": {
+            "get": {
+                "description": "Suggest group names or song titles starting with the typed prefix (at the start of the name or of any word in it), most popular first. Popularity is the number of songs with the name plus the number of requests to them since startup. Matching ignores case, punctuation and Cyrillic/Latin transliteration.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Autocomplete group names and song titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Typed prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "song",
+                        "description": "Field to suggest: group or song",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of suggestions (at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AutocompleteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid field or empty query",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Retrieve all genres as a tree: top-level genres with nested subgenres, each level ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the genre tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a genre, optionally under a parent genre (e.g. Alternative Rock under Rock). Genre names are unique across the tree, ignoring case, punctuation and transliteration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add a new genre",
+                "parameters": [
+                    {
+                        "description": "Genre",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input or unknown parent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "genre already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}": {
+            "put": {
+                "description": "Rename a genre or move it under another parent; without parent_id the genre becomes top-level. A genre cannot be moved under itself or its subgenres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated genre",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input, unknown parent or cycle",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "genre already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a genre without subgenres; its songs lose the genre",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid genre id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "genre has subgenres",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/info": {
+            "get": {
+                "description": "Retrieve detailed information about a song, add to database if not present. Names are matched tolerating typos, case and punctuation; if the song is unknown, the 404 response suggests similar songs. Featured artists given after \"feat.\", \"ft.\" or \"featuring\" (e.g. \"Ania feat. Bob\", \"Gamma (ft. Carl \u0026 Dan)\") are separated from the names and credited on the song; \",\" and \"\u0026\" without these markers are part of the name (e.g. \"Earth, Wind \u0026 Fire\").",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get song details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "external API error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "external API unavailable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text search over group names, song titles and lyrics with stemming. Results are ranked by relevance; each hit lists the matching verses with highlighted snippets.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Search songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query: words, \\",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchResult"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching songs"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "empty search query",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs": {
+            "get": {
+                "description": "Retrieve all songs with optional filtering and pagination. Each song lists the albums it appears on with its track position. Range filters include their bounds and skip songs without the value.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date or period: DD.MM.YYYY, YYYY-MM-DD, YYYY-MM, MM.YYYY or YYYY",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after the start of this date or period",
+                        "name": "release_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before the end of this date or period",
+                        "name": "release_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Release year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link",
+                        "name": "link",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Detected lyrics language (ISO 639-1 code, e.g. ru or en)",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the name or alias of a person credited on the song in any role",
+                        "name": "credited",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "composer",
+                            "lyricist",
+                            "producer",
+                            "featured"
+                        ],
+                        "type": "string",
+                        "description": "Role of the credited person; alone, selects songs with any credit in this role",
+                        "name": "credit_role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre ID or name; songs of its subgenres are included",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag; repeat to require several tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum duration in seconds",
+                        "name": "duration_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum duration in seconds",
+                        "name": "duration_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum tempo in beats per minute",
+                        "name": "bpm_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum tempo in beats per minute",
+                        "name": "bpm_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key tonic, e.g. C, F# or Bb",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "major",
+                            "minor"
+                        ],
+                        "type": "string",
+                        "description": "Key mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Explicit lyrics flag",
+                        "name": "explicit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISRC, with or without hyphens",
+                        "name": "isrc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-release_date,group",
+                        "description": "Comma-separated sort fields, prefix with - for descending (id, group, song, release_date, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor of a previous response; takes precedence over page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongList"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching songs"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid filter, sort or cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new song by group and title, enriching it with details from the external API. A song whose names differ from an existing one only by typos, case or punctuation is considered a duplicate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add a new song",
+                "parameters": [
+                    {
+                        "description": "New song",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewSongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "song already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "external API error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "external API unavailable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/trash": {
+            "get": {
+                "description": "Retrieve songs moved to the trash, most recently deleted first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get deleted songs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Song"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}": {
+            "put": {
+                "description": "Update an existing song by its ID. Track metadata fields omitted from the body keep their values and null clears them; an invalid ISRC, key, mode, duration or BPM is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated song data",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "song already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a song to the trash by its ID, or delete it permanently with purge=true",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete permanently, including songs in the trash",
+                        "name": "purge",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/analysis": {
+            "get": {
+                "description": "Word, line and section counts, unique-word ratio, most repeated lines, estimated rhyme scheme of each section and estimated reading and singing time. Sections are the same as in GET /songs/{id}/verses. The result is cached until the song's text changes; the X-Cache header reports HIT or MISS.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Analyze song lyrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LyricsAnalysis"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT if the analysis was served from the cache, otherwise MISS"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/chords": {
+            "get": {
+                "description": "Render the song's ChordPro sheet as plain text with chords above the lyrics, as an HTML fragment, or as ChordPro. transpose shifts the sounding pitch by the given number of semitones; capo re-voices the chords for a capo on that fret while keeping the pitch; accidentals selects sharps, flats or the spelling of the resulting key.",
+                "produces": [
+                    "text/plain",
+                    "text/html"
+                ],
+                "summary": "Render a chord sheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "text",
+                        "description": "Output format: text, html or chordpro",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Semitones to transpose by, from -11 to 11",
+                        "name": "transpose",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Capo fret to voice the chords for, from 0 to 11; defaults to the sheet's {capo}",
+                        "name": "capo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "auto",
+                        "description": "Accidentals: auto, sharp or flat",
+                        "name": "accidentals",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered chord sheet",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upload a ChordPro document for a song, either as the raw request body or as a multipart \"file\" field. Chords, directives and sections are validated; problems are returned with line numbers.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Import a ChordPro chord sheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ChordPro document",
+                        "name": "chords",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "ChordPro file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChordSheet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.InvalidLyrics"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the song's ChordPro chord sheet; the lyrics are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a chord sheet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/credits": {
+            "get": {
+                "description": "Retrieve the composers, lyricists, producers and featured artists of a song ordered by role and name",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List song credits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SongCredit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Credit a person or group on a song as composer, lyricist, producer or featured artist. The artist is found by name or alias ignoring case and transliteration, and created if unknown. Adding an existing credit returns it with status 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add a song credit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongCredit"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongCredit"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/credits/{artist_id}/{role}": {
+            "delete": {
+                "description": "Remove the credit of an artist with the given role from a song; the artist itself is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Remove a song credit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "artist_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "composer",
+                            "lyricist",
+                            "producer",
+                            "featured"
+                        ],
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid song id, artist id or role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/genres": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List song genres",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the genres of a song; an empty list removes all genres",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set song genres",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre IDs",
+                        "name": "genres",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SongGenresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input or unknown genre",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/lyrics/at": {
+            "get": {
+                "description": "Return the current and next synced lines for a playback position in milliseconds (with the LRC offset applied), and the current word for enhanced LRC lines",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get lyrics at a playback position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Playback position in milliseconds",
+                        "name": "ms",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LyricsPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid position",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/lyrics/lrc": {
+            "get": {
+                "description": "Download the song's synced lyrics as an LRC file",
+                "produces": [
+                    "text/plain"
+                ],
+                "summary": "Export synced lyrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "LRC file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upload LRC or enhanced LRC (word timestamps) lyrics for a song, either as the raw request body or as a multipart \"file\" field. Timestamps are validated; the lyrics are stored normalized and returned parsed.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Import synced lyrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "LRC file contents",
+                        "name": "lyrics",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "LRC file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncedLyrics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.InvalidLyrics"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the song's synced lyrics; the plain text is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete synced lyrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/restore": {
+            "post": {
+                "description": "Restore a song from the trash by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Restore a deleted song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "song already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/tags": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List song tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add user-defined tags to a song and return all its tags. Tags are stored lowercase with single spaces and without a leading #; tags the song already has are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add song tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid tag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/tags/{tag}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Remove a song tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid song id or tag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/translations": {
+            "get": {
+                "description": "Retrieve all translations of a song ordered by language code",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List song translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Translation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/translations/{lang}": {
+            "get": {
+                "description": "Retrieve the translation of a song into the given language (BCP 47 code such as ru, en or pt-BR)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a song translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid language code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Store the translation of a song into the given language together with its provenance: source (official, community or machine), translator and source URL. An existing translation into the same language is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add or replace a song translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the translation of a song into the given language",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a song translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid language code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/verses": {
+            "get": {
+                "description": "Retrieve the text of a song by its ID with pagination by sections (verses, choruses, etc.) or by lines. Sections come from [Verse 1] / [Chorus]-style labels or are detected automatically: blocks repeated in the text are choruses.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a song by ID with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated section types to return: verse, pre-chorus, chorus, bridge, intro, outro, hook, other",
+                        "name": "section",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "text",
+                            "sections",
+                            "lines"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "text: sections as strings; sections: structured sections; lines: individual lines",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code of a stored translation to return instead of the original",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "original: return each translated section (or line) next to the original one with the same index",
+                        "name": "with",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Sections (or lines) per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid section type, format or language",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Count songs by tag, most frequent first. Accepts the filters of GET /songs, so the counts describe the current selection and can be shown as facets.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get tag counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Beginning of the tag",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of tags (at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre ID or name; songs of its subgenres are included",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag; repeat to require several tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the name or alias of a person credited on the song",
+                        "name": "credited",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Detected lyrics language",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Album": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "description": "Исполнитель альбома; у сборников разных исполнителей не указывается",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2006-07-03"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Black Holes and Revelations"
+                },
+                "tracks": {
+                    "description": "Песни альбома в порядке следования; заполняются при чтении альбома по ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Track"
+                    }
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlbumType"
+                        }
+                    ],
+                    "example": "album"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AlbumList": {
+            "type": "object",
+            "properties": {
+                "albums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Album"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AlbumRequest": {
+            "type": "object",
+            "required": [
+                "title",
+                "type"
+            ],
+            "properties": {
+                "artist_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2006-07-03"
+                },
+                "song_ids": {
+                    "description": "ID песен в порядке следования",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Black Holes and Revelations"
+                },
+                "type": {
+                    "enum": [
+                        "album",
+                        "ep",
+                        "single",
+                        "compilation"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlbumType"
+                        }
+                    ],
+                    "example": "album"
+                }
+            }
+        },
+        "models.AlbumType": {
+            "type": "string",
+            "enum": [
+                "album",
+                "ep",
+                "single",
+                "compilation"
+            ],
+            "x-enum-comments": {
+                "AlbumTypeAlbum": "Студийный альбом",
+                "AlbumTypeCompilation": "Сборник; может не иметь исполнителя",
+                "AlbumTypeEP": "Мини-альбом",
+                "AlbumTypeSingle": "Сингл"
+            },
+            "x-enum-varnames": [
+                "AlbumTypeAlbum",
+                "AlbumTypeEP",
+                "AlbumTypeSingle",
+                "AlbumTypeCompilation"
+            ]
+        },
+        "models.Artist": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Rocket Baby Dolls"
+                    ]
+                },
+                "country": {
+                    "description": "Код страны ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "GB"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "formed_year": {
+                    "type": "integer",
+                    "example": 1994
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Каноническое название; под ним песни исполнителя возвращаются в поле group",
+                    "type": "string",
+                    "example": "Muse"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ArtistList": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Artist"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ArtistRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Rocket Baby Dolls"
+                    ]
+                },
+                "country": {
+                    "type": "string",
+                    "example": "GB"
+                },
+                "formed_year": {
+                    "type": "integer",
+                    "example": 1994
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Muse"
+                }
+            }
+        },
+        "models.AutocompleteResult": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "group"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AutocompleteSuggestion"
+                    }
+                }
+            }
+        },
+        "models.AutocompleteSuggestion": {
+            "type": "object",
+            "properties": {
+                "popularity": {
+                    "description": "Количество песен и обращений к ним",
+                    "type": "integer",
+                    "example": 42
+                },
+                "songs": {
+                    "description": "Количество песен с этим названием",
+                    "type": "integer",
+                    "example": 3
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Muse"
+                }
+            }
+        },
+        "models.ChordSheet": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "type": "string",
+                    "example": "Muse"
+                },
+                "capo": {
+                    "description": "Лад каподастра, для которого записаны аккорды",
+                    "type": "integer",
+                    "example": 2
+                },
+                "chords": {
+                    "description": "Использованные аккорды в порядке первого появления",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Em",
+                        "G",
+                        "D",
+                        "A"
+                    ]
+                },
+                "key": {
+                    "description": "Тональность по звучанию",
+                    "type": "string",
+                    "example": "Em"
+                },
+                "song_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Supermassive Black Hole"
+                }
+            }
+        },
+        "models.CreditRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Matt Bellamy"
+                },
+                "role": {
+                    "enum": [
+                        "composer",
+                        "lyricist",
+                        "producer",
+                        "featured"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CreditRole"
+                        }
+                    ],
+                    "example": "composer"
+                }
+            }
+        },
+        "models.CreditRole": {
+            "type": "string",
+            "enum": [
+                "composer",
+                "lyricist",
+                "producer",
+                "featured"
+            ],
+            "x-enum-comments": {
+                "CreditComposer": "Автор музыки",
+                "CreditFeatured": "Приглашённый исполнитель",
+                "CreditLyricist": "Автор текста",
+                "CreditProducer": "Продюсер"
+            },
+            "x-enum-varnames": [
+                "CreditComposer",
+                "CreditLyricist",
+                "CreditProducer",
+                "CreditFeatured"
+            ]
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "Дочерние жанры; заполняются при выводе дерева жанров",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Alternative Rock"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GenreRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Alternative Rock"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.InvalidLyrics": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid LRC"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LyricsProblem"
+                    }
+                }
+            }
+        },
+        "models.LyricSection": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Chorus"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "number": {
+                    "description": "Номер куплета и т. п., если части этого типа нумеруются",
+                    "type": "integer"
+                },
+                "repeat": {
+                    "description": "Часть повторяет ранее встречавшуюся",
+                    "type": "boolean"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SectionType"
+                        }
+                    ],
+                    "example": "chorus"
+                }
+            }
+        },
+        "models.LyricsAnalysis": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "reading_time_seconds": {
+                    "type": "number",
+                    "example": 42.6
+                },
+                "repeated_lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RepeatedLine"
+                    }
+                },
+                "rhyme_schemes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RhymeScheme"
+                    }
+                },
+                "section_types": {
+                    "description": "Количество частей каждого типа",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "sections": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "singing_time_seconds": {
+                    "type": "number",
+                    "example": 99
+                },
+                "song_id": {
+                    "type": "integer"
+                },
+                "syllables": {
+                    "type": "integer",
+                    "example": 198
+                },
+                "unique_word_ratio": {
+                    "type": "number",
+                    "example": 0.47
+                },
+                "unique_words": {
+                    "type": "integer",
+                    "example": 67
+                },
+                "words": {
+                    "type": "integer",
+                    "example": 142
+                }
+            }
+        },
+        "models.LyricsPosition": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Текущая строка; null, если первая строка ещё не началась",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SyncedLine"
+                        }
+                    ]
+                },
+                "next": {
+                    "description": "Следующая строка; null, если текущая строка последняя",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SyncedLine"
+                        }
+                    ]
+                },
+                "position_ms": {
+                    "type": "integer"
+                },
+                "song_id": {
+                    "type": "integer"
+                },
+                "word_index": {
+                    "description": "Номер текущего слова в строке (с 0), если у строки есть метки слов",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LyricsProblem": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "description": "Номер строки файла; 0 — ошибка относится к файлу целиком",
+                    "type": "integer",
+                    "example": 3
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid timestamp [00:75.00]: seconds must be less than 60"
+                }
+            }
+        },
+        "models.LyricsTag": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "ar"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Muse"
+                }
+            }
+        },
+        "models.Mode": {
+            "type": "string",
+            "enum": [
+                "major",
+                "minor"
+            ],
+            "x-enum-varnames": [
+                "ModeMajor",
+                "ModeMinor"
+            ]
+        },
+        "models.NewSongRequest": {
+            "type": "object",
+            "required": [
+                "group",
+                "song"
+            ],
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RepeatedLine": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Ooh baby, don't you know I suffer?"
+                }
+            }
+        },
+        "models.RhymeScheme": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "description": "Номер части в песне, как в GET /songs/{id}/verses",
+                    "type": "integer",
+                    "example": 1
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Verse 1"
+                },
+                "scheme": {
+                    "type": "string",
+                    "example": "ABAB"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SectionType"
+                        }
+                    ],
+                    "example": "verse"
+                }
+            }
+        },
+        "models.SearchHit": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VerseMatch"
+                    }
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "song": {
+                    "$ref": "#/definitions/models.Song"
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchHit"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SectionType": {
+            "type": "string",
+            "enum": [
+                "verse",
+                "pre-chorus",
+                "chorus",
+                "bridge",
+                "intro",
+                "outro",
+                "hook",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "SectionVerse",
+                "SectionPreChorus",
+                "SectionChorus",
+                "SectionBridge",
+                "SectionIntro",
+                "SectionOutro",
+                "SectionHook",
+                "SectionOther"
+            ]
+        },
+        "models.Song": {
+            "type": "object",
+            "properties": {
+                "albums": {
+                    "description": "Альбомы, на которых вышла песня; заполняются в списках песен",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SongAlbum"
+                    }
+                },
+                "artist_id": {
+                    "description": "Исполнитель песни; при сохранении находится (или создаётся) по названию группы, а группа приводится к его названию",
+                    "type": "integer",
+                    "example": 1
+                },
+                "bpm": {
+                    "description": "Темп в ударах в минуту",
+                    "type": "number",
+                    "example": 120
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "example": 212
+                },
+                "explicit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "group": {
+                    "description": "Уникальность пары группа+песня проверяется только среди неудалённых песен",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isrc": {
+                    "description": "International Standard Recording Code без дефисов: CC XXX YY NNNNN",
+                    "type": "string",
+                    "example": "GBAHT0500600"
+                },
+                "key": {
+                    "description": "Тоника тональности: C, C#, Db, ..., B",
+                    "type": "string",
+                    "example": "G"
+                },
+                "language": {
+                    "description": "Язык текста (код ISO 639-1), определяется автоматически при сохранении; пустая строка — язык не определён",
+                    "type": "string",
+                    "example": "ru"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Mode"
+                        }
+                    ],
+                    "example": "minor"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2006-07-16"
+                },
+                "sections": {
+                    "description": "Текст песни, разобранный на части (куплеты, припевы и т. п.); пересчитывается при сохранении",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LyricSection"
+                    }
+                },
+                "song": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongAlbum": {
+            "type": "object",
+            "properties": {
+                "album_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2006-07-03"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Black Holes and Revelations"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AlbumType"
+                        }
+                    ],
+                    "example": "album"
+                }
+            }
+        },
+        "models.SongCredit": {
+            "type": "object",
+            "properties": {
+                "artist_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Название исполнителя; только для чтения",
+                    "type": "string",
+                    "example": "Bob"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CreditRole"
+                        }
+                    ],
+                    "example": "featured"
+                },
+                "song_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongDetail": {
+            "type": "object",
+            "properties": {
+                "bpm": {
+                    "description": "Темп в ударах в минуту",
+                    "type": "number",
+                    "example": 120
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "example": 212
+                },
+                "explicit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "isrc": {
+                    "description": "International Standard Recording Code без дефисов: CC XXX YY NNNNN",
+                    "type": "string",
+                    "example": "GBAHT0500600"
+                },
+                "key": {
+                    "description": "Тоника тональности: C, C#, Db, ..., B",
+                    "type": "string",
+                    "example": "G"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Mode"
+                        }
+                    ],
+                    "example": "minor"
+                },
                 "release_date": {
+                    "type": "string",
+                    "example": "2006-07-16"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongGenresRequest": {
+            "type": "object",
+            "required": [
+                "genre_ids"
+            ],
+            "properties": {
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                }
+            }
+        },
+        "models.SongList": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "songs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Song"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongNotFound": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "song not found"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SongSuggestion"
+                    }
+                }
+            }
+        },
+        "models.SongSuggestion": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.92
+                },
+                "song": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SyncedLine": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Ooh baby, don't you know I suffer?"
+                },
+                "time_ms": {
+                    "type": "integer",
+                    "example": 12000
+                },
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncedWord"
+                    }
+                }
+            }
+        },
+        "models.SyncedLyrics": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncedLine"
+                    }
+                },
+                "offset_ms": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LyricsTag"
+                    }
+                }
+            }
+        },
+        "models.SyncedWord": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "baby"
+                },
+                "time_ms": {
+                    "type": "integer",
+                    "example": 12500
+                }
+            }
+        },
+        "models.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "summer"
+                }
+            }
+        },
+        "models.TagsRequest": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "road trip",
+                        "summer"
+                    ]
+                }
+            }
+        },
+        "models.Track": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "Muse"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "song": {
+                    "type": "string",
+                    "example": "Take a Bow"
+                },
+                "song_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.TracksRequest": {
+            "type": "object",
+            "required": [
+                "song_ids"
+            ],
+            "properties": {
+                "song_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        1
+                    ]
+                }
+            }
+        },
+        "models.Translation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "Код языка BCP 47 в канонической записи: ru, en, pt-BR",
+                    "type": "string",
+                    "example": "ru"
+                },
+                "sections": {
+                    "description": "Текст перевода, разобранный на части; части сопоставляются с частями оригинала по номеру",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LyricSection"
+                    }
+                },
+                "song_id": {
+                    "type": "integer"
+                },
+                "source": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TranslationSource"
+                        }
+                    ],
+                    "example": "community"
+                },
+                "source_url": {
+                    "type": "string",
+                    "example": "https://example.com/translations/42"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "translator": {
+                    "type": "string",
+                    "example": "Иван Петров"
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "models.TranslationRequest": {
+            "type": "object",
+            "required": [
+                "source",
+                "text"
+            ],
+            "properties": {
+                "source": {
+                    "enum": [
+                        "official",
+                        "community",
+                        "machine"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TranslationSource"
+                        }
+                    ],
+                    "example": "community"
+                },
+                "source_url": {
+                    "type": "string",
+                    "example": "https://example.com/translations/42"
                 },
                 "text": {
                     "type": "string"
+                },
+                "translator": {
+                    "type": "string",
+                    "example": "Иван Петров"
+                }
+            }
+        },
+        "models.TranslationSource": {
+            "type": "string",
+            "enum": [
+                "official",
+                "community",
+                "machine"
+            ],
+            "x-enum-comments": {
+                "TranslationCommunity": "Перевод пользователя или сообщества",
+                "TranslationMachine": "Машинный перевод",
+                "TranslationOfficial": "Официальный перевод (от исполнителя или издателя)"
+            },
+            "x-enum-varnames": [
+                "TranslationOfficial",
+                "TranslationCommunity",
+                "TranslationMachine"
+            ]
+        },
+        "models.VerseMatch": {
+            "type": "object",
+            "properties": {
+                "snippet": {
+                    "type": "string",
+                    "example": "Glaciers melting in the dead of night, and the \u003cb\u003esuperstars\u003c/b\u003e sucked into the \u003cb\u003esupermassive\u003c/b\u003e"
+                },
+                "verse": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         }
@@ -0,0 +1,179 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-tunes/models"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound возвращается, когда внешний API не знает запрошенную песню
+	ErrNotFound = errors.New("song not found in external API")
+	// ErrUnavailable возвращается, когда внешний API не ответил корректно после всех попыток
+	ErrUnavailable = errors.New("external API unavailable")
+	// ErrCircuitOpen возвращается без обращения к внешнему API, пока разомкнут предохранитель
+	ErrCircuitOpen = fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+)
+
+// Enricher получает подробную информацию о песне из внешнего источника
+type Enricher interface {
+	GetSongDetail(ctx context.Context, group, song string) (models.SongDetail, error)
+}
+
+// Config описывает параметры клиента внешнего API
+type Config struct {
+	BaseURL          string        // Базовый адрес внешнего API, например http://localhost:8081
+	Timeout          time.Duration // Таймаут одной попытки запроса
+	MaxRetries       int           // Количество повторов после первой неудачной попытки
+	BackoffBase      time.Duration // Начальная задержка перед повтором, удваивается с каждой попыткой
+	BackoffMax       time.Duration // Максимальная задержка перед повтором
+	BreakerThreshold int           // Количество подряд неудачных запросов, после которого предохранитель размыкается
+	BreakerCooldown  time.Duration // Время, через которое разомкнутый предохранитель пропускает пробный запрос
+}
+
+// Client обращается к внешнему API обогащения с повторами и предохранителем
+type Client struct {
+	config  Config
+	http    *http.Client
+	breaker *breaker
+}
+
+var _ Enricher = (*Client)(nil)
+
+func NewClient(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = 3 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.BackoffBase <= 0 {
+		config.BackoffBase = 200 * time.Millisecond
+	}
+	if config.BackoffMax < config.BackoffBase {
+		config.BackoffMax = config.BackoffBase
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &Client{
+		config:  config,
+		http:    &http.Client{},
+		breaker: newBreaker(config.BreakerThreshold, config.BreakerCooldown),
+	}
+}
+
+// GetSongDetail запрашивает у внешнего API подробности о песне.
+// Повторяет запрос при сетевых ошибках, таймаутах и ответах 5xx с экспоненциальной задержкой.
+func (client *Client) GetSongDetail(ctx context.Context, group, song string) (models.SongDetail, error) {
+	if !client.breaker.allow() {
+		log.Printf("WARNING: External API circuit breaker is open, skipping request for group '%s', song '%s'", group, song)
+		return models.SongDetail{}, ErrCircuitOpen
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= client.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := client.backoff(attempt)
+			log.Printf("DEBUG: Retrying external API request in %s (attempt %d of %d): %v", delay, attempt+1, client.config.MaxRetries+1, lastErr)
+			select {
+			case <-ctx.Done():
+				client.breaker.failure()
+				return models.SongDetail{}, fmt.Errorf("%w: %v", ErrUnavailable, ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+
+		detail, retryable, err := client.fetch(ctx, group, song)
+		if err == nil {
+			client.breaker.success()
+			return detail, nil
+		}
+		if errors.Is(err, ErrNotFound) {
+			// Ответ "не найдено" означает, что внешний API работает
+			client.breaker.success()
+			return models.SongDetail{}, err
+		}
+		lastErr = err
+		if !retryable || ctx.Err() != nil {
+			break
+		}
+	}
+
+	client.breaker.failure()
+	log.Printf("ERROR: External API request failed for group '%s', song '%s': %v", group, song, lastErr)
+	return models.SongDetail{}, lastErr
+}
+
+// fetch выполняет одну попытку запроса и сообщает, имеет ли смысл её повторить
+func (client *Client) fetch(ctx context.Context, group, song string) (models.SongDetail, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.config.Timeout)
+	defer cancel()
+
+	apiURL := fmt.Sprintf("%s/info?group=%s&song=%s", client.config.BaseURL, url.QueryEscape(group), url.QueryEscape(song))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return models.SongDetail{}, false, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	response, err := client.http.Do(request)
+	if err != nil {
+		return models.SongDetail{}, true, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return models.SongDetail{}, false, ErrNotFound
+	case response.StatusCode >= http.StatusInternalServerError, response.StatusCode == http.StatusTooManyRequests:
+		return models.SongDetail{}, true, fmt.Errorf("%w: status code %d", ErrUnavailable, response.StatusCode)
+	case response.StatusCode != http.StatusOK:
+		return models.SongDetail{}, false, fmt.Errorf("%w: unexpected status code %d", ErrUnavailable, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return models.SongDetail{}, true, fmt.Errorf("%w: failed to read response: %v", ErrUnavailable, err)
+	}
+
+	var payload songDetailResponse
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return models.SongDetail{}, false, fmt.Errorf("%w: failed to parse response: %v", ErrUnavailable, err)
+	}
+	detail := payload.SongDetail
+	// Некорректная дата релиза не мешает добавить песню с текстом и ссылкой: дата отбрасывается
+	if len(payload.ReleaseDate) > 0 {
+		if err := detail.ReleaseDate.UnmarshalJSON(payload.ReleaseDate); err != nil {
+			log.Printf("WARNING: Dropping invalid release date of '%s' - '%s' from external API: %v", group, song, err)
+		}
+	}
+	// Некорректные сведения о записи не мешают добавить песню: такие поля отбрасываются
+	if err := detail.TrackMetadata.Normalize(); err != nil {
+		log.Printf("WARNING: Dropping invalid track metadata of '%s' - '%s' from external API: %v", group, song, err)
+	}
+	return detail, false, nil
+}
+
+// songDetailResponse — ответ внешнего API; дата релиза перекрывает поле SongDetail и разбирается отдельно
+type songDetailResponse struct {
+	models.SongDetail
+	ReleaseDate json.RawMessage `json:"release_date"`
+}
+
+// backoff возвращает задержку перед повтором с номером attempt: BackoffBase * 2^(attempt-1) со случайным разбросом
+func (client *Client) backoff(attempt int) time.Duration {
+	delay := client.config.BackoffBase << (attempt - 1)
+	if delay <= 0 || delay > client.config.BackoffMax {
+		delay = client.config.BackoffMax
+	}
+	// Разброс до половины задержки, чтобы клиенты не повторяли запросы синхронно
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
@@ -0,0 +1,54 @@
+package enrichment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetSongDetailReleaseDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantDate string
+	}{
+		{"valid date", `{"release_date":"16.07.2006","text":"text","link":"link"}`, "2006-07-16"},
+		{"invalid date is dropped", `{"release_date":"someday","text":"text","link":"link"}`, ""},
+		{"non-string date is dropped", `{"release_date":2006,"text":"text","link":"link"}`, ""},
+		{"null date", `{"release_date":null,"text":"text","link":"link"}`, ""},
+		{"missing date", `{"text":"text","link":"link"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(Config{BaseURL: server.URL})
+			detail, err := client.GetSongDetail(context.Background(), "Muse", "Uprising")
+			if err != nil {
+				t.Fatalf("GetSongDetail() error = %v", err)
+			}
+			if got := detail.ReleaseDate.String(); got != tt.wantDate {
+				t.Errorf("release date = %q, want %q", got, tt.wantDate)
+			}
+			if detail.Text != "text" || detail.Link != "link" {
+				t.Errorf("detail = %+v, want text and link to be kept", detail)
+			}
+		})
+	}
+}
+
+func TestClientGetSongDetailMalformedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"text":`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL})
+	if _, err := client.GetSongDetail(context.Background(), "Muse", "Uprising"); err == nil {
+		t.Fatal("GetSongDetail() error = nil, want an error for a malformed body")
+	}
+}
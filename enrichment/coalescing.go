@@ -0,0 +1,53 @@
+package enrichment
+
+import (
+	"context"
+	"go-tunes/models"
+	"log"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CoalescingEnricher объединяет одновременные запросы к внешнему API для одной и той же песни:
+// пока запрос для пары группа+песня выполняется, остальные вызовы ждут и получают его результат.
+type CoalescingEnricher struct {
+	next  Enricher
+	group singleflight.Group
+}
+
+var _ Enricher = (*CoalescingEnricher)(nil)
+
+func NewCoalescingEnricher(next Enricher) *CoalescingEnricher {
+	return &CoalescingEnricher{next: next}
+}
+
+func (e *CoalescingEnricher) GetSongDetail(ctx context.Context, group, song string) (models.SongDetail, error) {
+	key := coalescingKey(group, song)
+	// Общий запрос не должен прерываться, если отменён контекст первого из ожидающих клиентов;
+	// его продолжительность ограничена таймаутами клиента.
+	sharedCtx := context.WithoutCancel(ctx)
+
+	result := e.group.DoChan(key, func() (interface{}, error) {
+		return e.next.GetSongDetail(sharedCtx, group, song)
+	})
+
+	select {
+	case <-ctx.Done():
+		return models.SongDetail{}, ctx.Err()
+	case res := <-result:
+		if res.Shared {
+			log.Printf("DEBUG: Shared external API result for group '%s', song '%s'", group, song)
+		}
+		if res.Err != nil {
+			return models.SongDetail{}, res.Err
+		}
+		return res.Val.(models.SongDetail), nil
+	}
+}
+
+// coalescingKey нормализует пару группа+песня: регистр и лишние пробелы не учитываются
+func coalescingKey(group, song string) string {
+	return strings.ToLower(strings.Join(strings.Fields(group), " ")) + "\x00" +
+		strings.ToLower(strings.Join(strings.Fields(song), " "))
+}
@@ -0,0 +1,148 @@
+// Package fuzzy сопоставляет названия групп и песен с учётом опечаток, регистра,
+// пунктуации, лишних пробелов и транслитерации.
+package fuzzy
+
+import (
+	"go-tunes/translit"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Normalize приводит название к ключу поиска: нижний регистр, без пунктуации и лишних пробелов,
+// кириллица и разные системы транслитерации сведены к одному латинскому написанию: "  Кино! " -> "kino"
+func Normalize(value string) string {
+	return translit.Key(value)
+}
+
+// Distance возвращает расстояние Левенштейна между строками в символах
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Similarity возвращает сходство нормализованных строк от 0 (ничего общего) до 1 (совпадают)
+func Similarity(a, b string) float64 {
+	a, b = Normalize(a), Normalize(b)
+	if a == b {
+		return 1
+	}
+	longest := max(len([]rune(a)), len([]rune(b)))
+	return 1 - float64(Distance(a, b))/float64(longest)
+}
+
+// Numbers возвращает числа из названия по порядку: арабские без ведущих нулей и римские
+// (из букв I, V и X, до XXXIX), переведённые в арабские: "Symphony No. 05, Part II" -> ["5", "2"].
+// Римские числа с L, C, D и M не учитываются: такие слова ("mix", "dim", "di") чаще оказываются обычными словами.
+func Numbers(value string) []string {
+	var numbers []string
+	// Слова выделяются без транслитерации: ключ поиска заменяет x на ks и ломает римские числа
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, word := range words {
+		if roman, ok := parseRoman(word); ok {
+			numbers = append(numbers, strconv.Itoa(roman))
+			continue
+		}
+		for _, digits := range strings.FieldsFunc(word, func(r rune) bool { return r < '0' || r > '9' }) {
+			if digits = strings.TrimLeft(digits, "0"); digits == "" {
+				digits = "0"
+			}
+			numbers = append(numbers, digits)
+		}
+	}
+	return numbers
+}
+
+// parseRoman разбирает римское число из букв i, v и x в канонической записи
+func parseRoman(word string) (int, bool) {
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10}
+	total := 0
+	for i := 0; i < len(word); i++ {
+		value, ok := values[word[i]]
+		if !ok {
+			return 0, false
+		}
+		if i+1 < len(word) && values[word[i+1]] > value {
+			total -= value
+		} else {
+			total += value
+		}
+	}
+	if total <= 0 || total >= 40 || formatRoman(total) != word {
+		return 0, false
+	}
+	return total, true
+}
+
+// formatRoman записывает число от 1 до 39 римскими цифрами в нижнем регистре
+func formatRoman(value int) string {
+	ones := []string{"", "i", "ii", "iii", "iv", "v", "vi", "vii", "viii", "ix"}
+	return strings.Repeat("x", value/10) + ones[value%10]
+}
+
+// Score описывает сходство пары группа+песня с искомой
+type Score struct {
+	Group float64 // Сходство названий групп
+	Song  float64 // Сходство названий песен
+	// SameNumbers сообщает, что числа в названиях совпадают: "Symphony No. 5" и "Symphony No. 9" —
+	// разные песни, как бы ни были похожи названия
+	SameNumbers bool
+}
+
+// Compare сравнивает искомую пару группа+песня с существующей
+func Compare(group, song, candidateGroup, candidateSong string) Score {
+	return Score{
+		Group:       Similarity(group, candidateGroup),
+		Song:        Similarity(song, candidateSong),
+		SameNumbers: slices.Equal(Numbers(group), Numbers(candidateGroup)) && slices.Equal(Numbers(song), Numbers(candidateSong)),
+	}
+}
+
+// Total возвращает общую оценку сходства, по которой упорядочиваются варианты
+func (s Score) Total() float64 {
+	return (s.Group + s.Song) / 2
+}
+
+// Matcher определяет пороги сходства
+type Matcher struct {
+	// MatchThreshold — минимальное сходство и группы, и песни, при котором запись считается той же песней
+	MatchThreshold float64
+	// SuggestThreshold — минимальная общая оценка, при которой запись предлагается в подсказках "возможно, вы имели в виду"
+	SuggestThreshold float64
+	// MaxSuggestions — максимальное количество подсказок
+	MaxSuggestions int
+}
+
+// DefaultMatcher используется, если пороги не заданы в конфигурации
+var DefaultMatcher = Matcher{MatchThreshold: 0.85, SuggestThreshold: 0.5, MaxSuggestions: 5}
+
+// IsMatch сообщает, достаточно ли сходство, чтобы считать записи одной песней.
+// Названия с разными числами одной песней не считаются: такие записи только предлагаются в подсказках.
+func (m Matcher) IsMatch(score Score) bool {
+	return score.SameNumbers && score.Group >= m.MatchThreshold && score.Song >= m.MatchThreshold
+}
+
+// IsSuggestion сообщает, стоит ли предлагать запись пользователю
+func (m Matcher) IsSuggestion(score Score) bool {
+	return score.Total() >= m.SuggestThreshold
+}
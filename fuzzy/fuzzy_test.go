@@ -0,0 +1,93 @@
+package fuzzy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"kino", "kino", 0},
+		{"kino", "kin", 1},
+		{"muse", "mose", 1},
+		{"kitten", "sitting", 3},
+		{"звезда", "звезды", 1},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("  Кино! ", "kino"); got != 1 {
+		t.Errorf("Similarity of transliterated names = %v, want 1", got)
+	}
+	if got := Similarity("Supermassive Black Hole", "Supermasive Black Hole"); got < DefaultMatcher.MatchThreshold {
+		t.Errorf("Similarity of a typo = %v, want at least %v", got, DefaultMatcher.MatchThreshold)
+	}
+	if got := Similarity("Muse", "Queen"); got >= DefaultMatcher.SuggestThreshold {
+		t.Errorf("Similarity of different names = %v, want less than %v", got, DefaultMatcher.SuggestThreshold)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"Supermassive Black Hole", nil},
+		{"Symphony No. 5", []string{"5"}},
+		{"Symphony No. 05, Part II", []string{"5", "2"}},
+		{"Track10", []string{"10"}},
+		{"Blink-182", []string{"182"}},
+		{"Vol. IV", []string{"4"}},
+		{"Chapter XXXIX", []string{"39"}},
+		{"Часть 2", []string{"2"}},
+		{"Remix", nil},
+		{"Mix", nil},
+		{"iiii", nil},
+		{"Vivid", nil},
+	}
+	for _, tt := range tests {
+		if got := Numbers(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("Numbers(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMatcherIsMatch(t *testing.T) {
+	tests := []struct {
+		group, song                   string
+		candidateGroup, candidateSong string
+		want                          bool
+	}{
+		{"Muse", "Supermasive Black Hole", "Muse", "Supermassive Black Hole", true},
+		{"Kino", "Zvezda", "Кино", "Звезда", true},
+		{"Beethoven", "Symphony No. 5", "Beethoven", "Symphony No. 9", false},
+		{"The Testers", "Track 10", "The Testers", "Track 11", false},
+		{"The Testers", "Part II", "The Testers", "Part III", false},
+		{"Beethoven", "Symphny No. 5", "Beethoven", "Symphony No. 5", true},
+		{"Blink-182", "Adams Song", "Blink-183", "Adams Song", false},
+		{"Muse", "Uprising", "Queen", "Uprising", false},
+	}
+	for _, tt := range tests {
+		score := Compare(tt.group, tt.song, tt.candidateGroup, tt.candidateSong)
+		if got := DefaultMatcher.IsMatch(score); got != tt.want {
+			t.Errorf("IsMatch(%q - %q, %q - %q) = %v, want %v (score %+v)",
+				tt.group, tt.song, tt.candidateGroup, tt.candidateSong, got, tt.want, score)
+		}
+	}
+}
+
+func TestMatcherSuggestsNumberedTitles(t *testing.T) {
+	score := Compare("Beethoven", "Symphony No. 5", "Beethoven", "Symphony No. 9")
+	if !DefaultMatcher.IsSuggestion(score) {
+		t.Errorf("IsSuggestion(%+v) = false, want titles differing by a number to be suggested", score)
+	}
+}
@@ -0,0 +1,250 @@
+// Package langdetect определяет язык текста без внешних сервисов. Сначала по буквам определяется
+// письменность: для письменностей, которыми пишут на одном языке (хангыль, греческий, грузинский и т. п.),
+// этого достаточно. Языки на кириллице и латинице различаются по частотам n-грамм (метод Кавнара — Тренкла):
+// профиль текста сравнивается с профилями, построенными по встроенным образцам текстов (каталог corpus).
+package langdetect
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// profileSize — количество самых частых n-грамм в профиле языка и текста
+	profileSize = 400
+	// maxGram — наибольшая длина n-граммы
+	maxGram = 3
+	// MinLetters — наименьшее количество букв, по которому определяется язык; для более коротких текстов язык не определяется
+	MinLetters = 20
+)
+
+//go:embed corpus/*.txt
+var corpus embed.FS
+
+// script — письменность текста
+type script int
+
+const (
+	scriptOther script = iota
+	scriptLatin
+	scriptCyrillic
+	scriptGreek
+	scriptArabic
+	scriptHebrew
+	scriptGeorgian
+	scriptArmenian
+	scriptHangul
+	scriptKana
+	scriptHan
+)
+
+// singleLanguage — языки письменностей, которые определяются без сравнения профилей
+var singleLanguage = map[script]string{
+	scriptGreek:    "el",
+	scriptArabic:   "ar",
+	scriptHebrew:   "he",
+	scriptGeorgian: "ka",
+	scriptArmenian: "hy",
+	scriptHangul:   "ko",
+	scriptKana:     "ja",
+	scriptHan:      "zh",
+}
+
+// profile — ранги n-грамм языка: 0 — самая частая
+type profile map[string]int
+
+// languageProfile — профиль языка из встроенного образца
+type languageProfile struct {
+	language string
+	script   script
+	ranks    profile
+}
+
+// profiles строятся один раз при загрузке пакета
+var profiles = loadProfiles()
+
+func loadProfiles() []languageProfile {
+	entries, err := corpus.ReadDir("corpus")
+	if err != nil {
+		panic("langdetect: " + err.Error())
+	}
+	result := make([]languageProfile, 0, len(entries))
+	for _, entry := range entries {
+		data, err := corpus.ReadFile(path.Join("corpus", entry.Name()))
+		if err != nil {
+			panic("langdetect: " + err.Error())
+		}
+		text := string(data)
+		dominant, _ := dominantScript(text)
+		result = append(result, languageProfile{
+			language: strings.TrimSuffix(entry.Name(), ".txt"),
+			script:   dominant,
+			ranks:    buildProfile(text),
+		})
+	}
+	return result
+}
+
+// Result — определённый язык и уверенность от 0 до 1
+type Result struct {
+	Language   string // Код языка ISO 639-1; пустая строка — язык не определён
+	Confidence float64
+}
+
+// Languages возвращает коды языков, которые умеет определять пакет
+func Languages() []string {
+	languages := make([]string, 0, len(profiles)+len(singleLanguage))
+	for _, profile := range profiles {
+		languages = append(languages, profile.language)
+	}
+	for _, language := range singleLanguage {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Detect определяет язык текста. Если букв меньше MinLetters или письменность не поддерживается,
+// возвращается пустой результат.
+func Detect(text string) Result {
+	dominant, share := dominantScript(text)
+	if dominant == scriptOther {
+		return Result{}
+	}
+	if language, ok := singleLanguage[dominant]; ok {
+		return Result{Language: language, Confidence: share}
+	}
+
+	ranks := buildProfile(text)
+	best, second := -1, -1
+	distances := make([]int, len(profiles))
+	for i, candidate := range profiles {
+		if candidate.script != dominant {
+			continue
+		}
+		distances[i] = distance(ranks, candidate.ranks)
+		if best < 0 || distances[i] < distances[best] {
+			best, second = i, best
+		} else if second < 0 || distances[i] < distances[second] {
+			second = i
+		}
+	}
+	if best < 0 {
+		return Result{}
+	}
+	confidence := share
+	if second >= 0 && distances[second] > 0 {
+		// Уверенность тем выше, чем дальше от текста второй по близости язык
+		confidence *= min(1, 5*float64(distances[second]-distances[best])/float64(distances[second]))
+	}
+	return Result{Language: profiles[best].language, Confidence: confidence}
+}
+
+// dominantScript возвращает письменность большинства букв текста и долю этих букв.
+// Для японского учитывается, что кана обычно перемежается с иероглифами.
+func dominantScript(text string) (script, float64) {
+	counts := make(map[script]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		counts[scriptOf(r)]++
+	}
+	if letters < MinLetters {
+		return scriptOther, 0
+	}
+	if counts[scriptKana] > 0 && counts[scriptKana]*10 >= counts[scriptHan] {
+		counts[scriptKana] += counts[scriptHan]
+		counts[scriptHan] = 0
+	}
+	dominant, top := scriptOther, 0
+	for candidate, count := range counts {
+		if count > top || (count == top && candidate < dominant) {
+			dominant, top = candidate, count
+		}
+	}
+	return dominant, float64(top) / float64(letters)
+}
+
+func scriptOf(r rune) script {
+	switch {
+	case unicode.Is(unicode.Latin, r):
+		return scriptLatin
+	case unicode.Is(unicode.Cyrillic, r):
+		return scriptCyrillic
+	case unicode.Is(unicode.Greek, r):
+		return scriptGreek
+	case unicode.Is(unicode.Arabic, r):
+		return scriptArabic
+	case unicode.Is(unicode.Hebrew, r):
+		return scriptHebrew
+	case unicode.Is(unicode.Georgian, r):
+		return scriptGeorgian
+	case unicode.Is(unicode.Armenian, r):
+		return scriptArmenian
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+		return scriptKana
+	case unicode.Is(unicode.Han, r):
+		return scriptHan
+	default:
+		return scriptOther
+	}
+}
+
+// buildProfile строит профиль текста: n-граммы длиной от 1 до maxGram внутри слов, дополненных пробелами по краям,
+// упорядоченные по убыванию частоты
+func buildProfile(text string) profile {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' && r != '’' })
+	for _, word := range words {
+		runes := []rune(" " + strings.Trim(word, "'’") + " ")
+		for n := 1; n <= maxGram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				gram := string(runes[i : i+n])
+				if gram != " " {
+					counts[gram]++
+				}
+			}
+		}
+	}
+
+	grams := make([]string, 0, len(counts))
+	for gram := range counts {
+		grams = append(grams, gram)
+	}
+	sort.Slice(grams, func(i, j int) bool {
+		if counts[grams[i]] != counts[grams[j]] {
+			return counts[grams[i]] > counts[grams[j]]
+		}
+		return grams[i] < grams[j]
+	})
+	if len(grams) > profileSize {
+		grams = grams[:profileSize]
+	}
+	ranks := make(profile, len(grams))
+	for i, gram := range grams {
+		ranks[gram] = i
+	}
+	return ranks
+}
+
+// distance — расстояние "вне места": сумма разностей рангов n-грамм текста и языка,
+// для n-грамм, которых нет в профиле языка, — наибольший штраф
+func distance(text, language profile) int {
+	total := 0
+	for gram, rank := range text {
+		if languageRank, ok := language[gram]; ok {
+			total += max(rank-languageRank, languageRank-rank)
+		} else {
+			total += profileSize
+		}
+	}
+	return total
+}
@@ -0,0 +1,46 @@
+package langdetect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Ooh baby, don't you know I suffer? Ooh baby, can you hear me moan? You caught me under false pretenses", "en"},
+		{"russian", "Белый снег, серый лёд на растрескавшейся земле. Одеялом лоскутным на ней город в дорожной петле", "ru"},
+		{"ukrainian", "Ще не вмерла України і слава, і воля, ще нам, браття молодії, усміхнеться доля", "uk"},
+		{"german", "Ich bin der Welt abhanden gekommen, mit der ich sonst viele Zeit verdorben, sie hat so lange nichts von mir vernommen", "de"},
+		{"spanish", "Yo no sé mañana si estaremos juntos, si se acaba el mundo, yo no sé si soy para ti", "es"},
+		{"greek by script", "Σ' αγαπώ γιατί είσαι ωραία, σ' αγαπώ γιατί είσαι εσύ", "el"},
+		{"too short", "Hello", ""},
+		{"no letters", "1234567890 !!! ??? 1234567890 ... 1234567890", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.text)
+			if got.Language != tt.want {
+				t.Errorf("Detect() = %+v, want %q", got, tt.want)
+			}
+			if tt.want != "" && (got.Confidence <= 0 || got.Confidence > 1) {
+				t.Errorf("Detect() confidence = %v, want a value in (0, 1]", got.Confidence)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	languages := Languages()
+	if !slices.IsSorted(languages) {
+		t.Errorf("Languages() = %q, want a sorted list", languages)
+	}
+	for _, language := range []string{"en", "ru", "uk", "el", "ja"} {
+		if !slices.Contains(languages, language) {
+			t.Errorf("Languages() = %q, want it to contain %q", languages, language)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Package lrc разбирает и формирует тексты песен с синхронизацией по времени в формате LRC,
+// включая расширенный формат с метками времени отдельных слов (<mm:ss.xx>).
+package lrc
+
+import (
+	"fmt"
+	"go-tunes/models"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxProblems ограничивает количество ошибок, о которых сообщает Parse
+const maxProblems = 20
+
+var (
+	tagPattern       = regexp.MustCompile(`^\[([^\]]*)\]`)
+	timePattern      = regexp.MustCompile(`^(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?$`)
+	metadataPattern  = regexp.MustCompile(`^([A-Za-z#]+):(.*)$`)
+	wordStampPattern = regexp.MustCompile(`<([^>]*)>`)
+)
+
+// metadataOrder — порядок стандартных тегов при формировании файла
+var metadataOrder = []string{"ar", "ti", "al", "au", "by", "length", "re", "ve"}
+
+// ValidationError содержит все найденные в файле ошибки
+type ValidationError struct {
+	Problems []models.LyricsProblem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		if problem.Line > 0 {
+			messages[i] = fmt.Sprintf("line %d: %s", problem.Line, problem.Message)
+		} else {
+			messages[i] = problem.Message
+		}
+	}
+	return "invalid LRC: " + strings.Join(messages, "; ")
+}
+
+// Parse разбирает файл LRC. Строка может содержать несколько меток времени ([00:12.00][01:30.50]текст) —
+// тогда она повторяется для каждой метки. Метки слов <mm:ss.xx> расширенного формата должны идти по возрастанию
+// и не раньше метки строки. Строки упорядочиваются по времени.
+func Parse(data string) (*models.SyncedLyrics, error) {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	lyrics := &models.SyncedLyrics{Tags: []models.LyricsTag{}, Lines: []models.SyncedLine{}}
+	var problems []models.LyricsProblem
+	report := func(line int, format string, args ...interface{}) {
+		problems = append(problems, models.LyricsProblem{Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for number, raw := range strings.Split(data, "\n") {
+		number++
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			report(number, "missing timestamp")
+			continue
+		}
+
+		var times []int64
+		rest := line
+		valid := true
+		for {
+			match := tagPattern.FindStringSubmatch(rest)
+			if match == nil {
+				break
+			}
+			if ms, ok, err := parseTimestamp(match[1]); ok {
+				if err != nil {
+					report(number, "%v", err)
+					valid = false
+				}
+				times = append(times, ms)
+			} else if len(times) == 0 && len(match[0]) == len(rest) && metadataPattern.MatchString(match[1]) {
+				// Строка метаданных: [ar:Исполнитель]
+				meta := metadataPattern.FindStringSubmatch(match[1])
+				key, value := strings.ToLower(meta[1]), strings.TrimSpace(meta[2])
+				if key == "offset" {
+					offset, err := strconv.ParseInt(strings.TrimPrefix(value, "+"), 10, 64)
+					if err != nil {
+						report(number, "invalid offset %q", value)
+						valid = false
+					}
+					lyrics.OffsetMs = offset
+				} else {
+					lyrics.Tags = append(lyrics.Tags, models.LyricsTag{Key: key, Value: value})
+				}
+			} else {
+				report(number, "invalid tag [%s]", match[1])
+				valid = false
+			}
+			rest = rest[len(match[0]):]
+		}
+		if !valid || len(times) == 0 {
+			if valid && strings.TrimSpace(rest) != "" {
+				report(number, "missing timestamp")
+			}
+			continue
+		}
+
+		text, words, err := parseWords(rest)
+		if err != nil {
+			report(number, "%v", err)
+			continue
+		}
+		for _, ms := range times {
+			if len(words) > 0 && words[0].TimeMs < ms {
+				report(number, "word timestamp %s is before line timestamp %s", formatTimestamp(words[0].TimeMs), formatTimestamp(ms))
+				break
+			}
+			lyrics.Lines = append(lyrics.Lines, models.SyncedLine{TimeMs: ms, Text: text, Words: words})
+		}
+	}
+
+	if len(problems) == 0 && len(lyrics.Lines) == 0 {
+		report(0, "no timed lines")
+	}
+	if len(problems) > 0 {
+		if len(problems) > maxProblems {
+			problems = problems[:maxProblems]
+		}
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	sort.SliceStable(lyrics.Lines, func(i, j int) bool { return lyrics.Lines[i].TimeMs < lyrics.Lines[j].TimeMs })
+	return lyrics, nil
+}
+
+// parseTimestamp разбирает метку времени mm:ss, mm:ss.x, mm:ss.xx или mm:ss.xxx.
+// Второе значение сообщает, похожа ли метка на метку времени; ошибка означает недопустимое значение.
+func parseTimestamp(value string) (int64, bool, error) {
+	match := timePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, false, nil
+	}
+	minutes, _ := strconv.ParseInt(match[1], 10, 64)
+	seconds, _ := strconv.ParseInt(match[2], 10, 64)
+	if seconds >= 60 {
+		return 0, true, fmt.Errorf("invalid timestamp [%s]: seconds must be less than 60", value)
+	}
+	var fraction int64
+	if match[3] != "" {
+		fraction, _ = strconv.ParseInt(match[3], 10, 64)
+		for i := len(match[3]); i < 3; i++ {
+			fraction *= 10
+		}
+	}
+	return (minutes*60+seconds)*1000 + fraction, true, nil
+}
+
+// parseWords выделяет из текста строки метки слов расширенного формата
+func parseWords(text string) (string, []models.SyncedWord, error) {
+	stamps := wordStampPattern.FindAllStringSubmatchIndex(text, -1)
+	if stamps == nil {
+		return strings.TrimSpace(text), nil, nil
+	}
+
+	var words []models.SyncedWord
+	var plain strings.Builder
+	plain.WriteString(text[:stamps[0][0]])
+	for i, stamp := range stamps {
+		ms, ok, err := parseTimestamp(text[stamp[2]:stamp[3]])
+		if !ok {
+			return "", nil, fmt.Errorf("invalid word timestamp <%s>", text[stamp[2]:stamp[3]])
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if len(words) > 0 && ms < words[len(words)-1].TimeMs {
+			return "", nil, fmt.Errorf("word timestamp <%s> goes back in time", text[stamp[2]:stamp[3]])
+		}
+		end := len(text)
+		if i+1 < len(stamps) {
+			end = stamps[i+1][0]
+		}
+		word := text[stamp[1]:end]
+		plain.WriteString(word)
+		if strings.TrimSpace(word) != "" {
+			words = append(words, models.SyncedWord{TimeMs: ms, Text: strings.TrimSpace(word)})
+		}
+	}
+	return strings.Join(strings.Fields(plain.String()), " "), words, nil
+}
+
+// Format формирует файл LRC: теги метаданных, смещение и строки по времени.
+// Строки с метками слов записываются в расширенном формате.
+func Format(lyrics *models.SyncedLyrics) string {
+	var builder strings.Builder
+	tags := append([]models.LyricsTag{}, lyrics.Tags...)
+	sort.SliceStable(tags, func(i, j int) bool { return tagRank(tags[i].Key) < tagRank(tags[j].Key) })
+	for _, tag := range tags {
+		fmt.Fprintf(&builder, "[%s:%s]\n", tag.Key, tag.Value)
+	}
+	if lyrics.OffsetMs != 0 {
+		fmt.Fprintf(&builder, "[offset:%+d]\n", lyrics.OffsetMs)
+	}
+	for _, line := range lyrics.Lines {
+		builder.WriteString("[" + formatTimestamp(line.TimeMs) + "]")
+		if len(line.Words) == 0 {
+			builder.WriteString(line.Text)
+		} else {
+			for i, word := range line.Words {
+				if i > 0 {
+					builder.WriteString(" ")
+				}
+				builder.WriteString("<" + formatTimestamp(word.TimeMs) + ">" + word.Text)
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func tagRank(key string) int {
+	for i, known := range metadataOrder {
+		if key == known {
+			return i
+		}
+	}
+	return len(metadataOrder)
+}
+
+// formatTimestamp записывает время в виде mm:ss.xx, а если время не кратно 10 мс — mm:ss.xxx
+func formatTimestamp(ms int64) string {
+	minutes, rest := ms/60000, ms%60000
+	seconds, fraction := rest/1000, rest%1000
+	if fraction%10 == 0 {
+		return fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, fraction/10)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, fraction)
+}
+
+// At возвращает текущую и следующую строки для позиции воспроизведения positionMs, а для строки
+// с метками слов — номер текущего слова. Времена возвращаемых строк скорректированы на смещение [offset].
+func At(lyrics *models.SyncedLyrics, positionMs int64) (current, next *models.SyncedLine, wordIndex *int) {
+	position := positionMs + lyrics.OffsetMs
+	index := sort.Search(len(lyrics.Lines), func(i int) bool { return lyrics.Lines[i].TimeMs > position }) - 1
+	if index+1 < len(lyrics.Lines) {
+		next = shifted(lyrics.Lines[index+1], lyrics.OffsetMs)
+	}
+	if index < 0 {
+		return nil, next, nil
+	}
+	line := lyrics.Lines[index]
+	current = shifted(line, lyrics.OffsetMs)
+	if len(line.Words) > 0 {
+		word := sort.Search(len(line.Words), func(i int) bool { return line.Words[i].TimeMs > position }) - 1
+		if word >= 0 {
+			wordIndex = &word
+		}
+	}
+	return current, next, wordIndex
+}
+
+// shifted возвращает копию строки со временем, скорректированным на смещение
+func shifted(line models.SyncedLine, offsetMs int64) *models.SyncedLine {
+	line.TimeMs -= offsetMs
+	if len(line.Words) > 0 {
+		words := make([]models.SyncedWord, len(line.Words))
+		for i, word := range line.Words {
+			words[i] = models.SyncedWord{TimeMs: word.TimeMs - offsetMs, Text: word.Text}
+		}
+		line.Words = words
+	}
+	return &line
+}
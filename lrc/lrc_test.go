@@ -0,0 +1,173 @@
+package lrc
+
+import (
+	"errors"
+	"go-tunes/models"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *models.SyncedLyrics
+	}{
+		{
+			name: "lines are sorted by time",
+			data: "[00:12.00]Second\n[00:01.50]First\n",
+			want: &models.SyncedLyrics{
+				Tags: []models.LyricsTag{},
+				Lines: []models.SyncedLine{
+					{TimeMs: 1500, Text: "First"},
+					{TimeMs: 12000, Text: "Second"},
+				},
+			},
+		},
+		{
+			name: "metadata and offset",
+			data: "\ufeff[ar: Muse ]\r\n[TI:Uprising]\r\n[offset:+250]\r\n[00:05]Go\r\n",
+			want: &models.SyncedLyrics{
+				Tags:     []models.LyricsTag{{Key: "ar", Value: "Muse"}, {Key: "ti", Value: "Uprising"}},
+				OffsetMs: 250,
+				Lines:    []models.SyncedLine{{TimeMs: 5000, Text: "Go"}},
+			},
+		},
+		{
+			name: "repeated timestamps repeat the line",
+			data: "[00:10.00][01:30.5]Chorus",
+			want: &models.SyncedLyrics{
+				Tags: []models.LyricsTag{},
+				Lines: []models.SyncedLine{
+					{TimeMs: 10000, Text: "Chorus"},
+					{TimeMs: 90500, Text: "Chorus"},
+				},
+			},
+		},
+		{
+			name: "millisecond precision",
+			data: "[02:03.456]Line",
+			want: &models.SyncedLyrics{
+				Tags:  []models.LyricsTag{},
+				Lines: []models.SyncedLine{{TimeMs: 123456, Text: "Line"}},
+			},
+		},
+		{
+			name: "word timestamps",
+			data: "[00:12.00]<00:12.00>Ooh <00:12.50>baby",
+			want: &models.SyncedLyrics{
+				Tags: []models.LyricsTag{},
+				Lines: []models.SyncedLine{{
+					TimeMs: 12000,
+					Text:   "Ooh baby",
+					Words:  []models.SyncedWord{{TimeMs: 12000, Text: "Ooh"}, {TimeMs: 12500, Text: "baby"}},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []models.LyricsProblem
+	}{
+		{"empty file", "", []models.LyricsProblem{{Line: 0, Message: "no timed lines"}}},
+		{"missing timestamp", "[00:01.00]One\nTwo", []models.LyricsProblem{{Line: 2, Message: "missing timestamp"}}},
+		{"seconds out of range", "[00:75.00]One", []models.LyricsProblem{{Line: 1, Message: "invalid timestamp [00:75.00]: seconds must be less than 60"}}},
+		{"invalid offset", "[offset:soon]\n[00:01]One", []models.LyricsProblem{{Line: 1, Message: `invalid offset "soon"`}}},
+		{"word before line", "[00:10.00]<00:09.00>Early", []models.LyricsProblem{{Line: 1, Message: "word timestamp 00:09.00 is before line timestamp 00:10.00"}}},
+		{"word going back", "[00:10.00]<00:11.00>One <00:10.50>Two", []models.LyricsProblem{{Line: 1, Message: "word timestamp <00:10.50> goes back in time"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			var validation *ValidationError
+			if !errors.As(err, &validation) {
+				t.Fatalf("Parse() error = %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validation.Problems, tt.want) {
+				t.Errorf("Parse() problems = %+v, want %+v", validation.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	lyrics := &models.SyncedLyrics{
+		Tags:     []models.LyricsTag{{Key: "al", Value: "Black Holes"}, {Key: "ar", Value: "Muse"}},
+		OffsetMs: -100,
+		Lines: []models.SyncedLine{
+			{TimeMs: 1500, Text: "First"},
+			{TimeMs: 123456, Text: "Ooh baby", Words: []models.SyncedWord{{TimeMs: 123456, Text: "Ooh"}, {TimeMs: 124000, Text: "baby"}}},
+		},
+	}
+	want := "[ar:Muse]\n[al:Black Holes]\n[offset:-100]\n[00:01.50]First\n[02:03.456]<02:03.456>Ooh <02:04.00>baby\n"
+	if got := Format(lyrics); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	data := "[ar:Muse]\n[ti:Uprising]\n[offset:+250]\n[00:05.00]Paranoia is in bloom\n[00:12.00]<00:12.00>The <00:12.30>PR\n"
+	lyrics, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := Format(lyrics); got != data {
+		t.Errorf("Format(Parse()) = %q, want %q", got, data)
+	}
+}
+
+func TestAt(t *testing.T) {
+	lyrics := &models.SyncedLyrics{
+		OffsetMs: 500,
+		Lines: []models.SyncedLine{
+			{TimeMs: 1000, Text: "One"},
+			{TimeMs: 2000, Text: "Two", Words: []models.SyncedWord{{TimeMs: 2000, Text: "T"}, {TimeMs: 2400, Text: "wo"}}},
+		},
+	}
+	tests := []struct {
+		positionMs  int64
+		current     string
+		next        string
+		wantWord    int
+		wantHasWord bool
+	}{
+		{0, "", "One", 0, false},
+		{500, "One", "Two", 0, false},
+		{1600, "Two", "", 0, true},
+		{2000, "Two", "", 1, true},
+	}
+	for _, tt := range tests {
+		current, next, word := At(lyrics, tt.positionMs)
+		if got := lineText(current); got != tt.current {
+			t.Errorf("At(%d) current = %q, want %q", tt.positionMs, got, tt.current)
+		}
+		if got := lineText(next); got != tt.next {
+			t.Errorf("At(%d) next = %q, want %q", tt.positionMs, got, tt.next)
+		}
+		if (word != nil) != tt.wantHasWord || (word != nil && *word != tt.wantWord) {
+			t.Errorf("At(%d) word = %v, want %d (present: %v)", tt.positionMs, word, tt.wantWord, tt.wantHasWord)
+		}
+	}
+}
+
+func lineText(line *models.SyncedLine) string {
+	if line == nil {
+		return ""
+	}
+	return line.Text
+}
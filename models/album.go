@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// AlbumType — тип релиза
+type AlbumType string
+
+const (
+    AlbumTypeAlbum       AlbumType = "album"       // Студийный альбом
+    AlbumTypeEP          AlbumType = "ep"          // Мини-альбом
+    AlbumTypeSingle      AlbumType = "single"      // Сингл
+    AlbumTypeCompilation AlbumType = "compilation" // Сборник; может не иметь исполнителя
+)
+
+// Album — альбом, EP, сингл или сборник с упорядоченным списком песен
+type Album struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+    Title     string    `json:"title" example:"Black Holes and Revelations"`
+    // Исполнитель альбома; у сборников разных исполнителей не указывается
+    ArtistID    *uint       `gorm:"index:idx_albums_artist_id" json:"artist_id" example:"1"`
+    ReleaseDate ReleaseDate `gorm:"embedded;embeddedPrefix:release_" json:"release_date" swaggertype:"string" example:"2006-07-03"`
+    Type        AlbumType   `json:"type" example:"album"`
+    // Песни альбома в порядке следования; заполняются при чтении альбома по ID
+    Tracks []Track `gorm:"-" json:"tracks,omitempty"`
+}
+
+// AlbumTrack — позиция песни в списке композиций альбома
+type AlbumTrack struct {
+    AlbumID  uint `gorm:"primaryKey"`
+    SongID   uint `gorm:"primaryKey;index:idx_album_tracks_song_id"`
+    Position int  // Номер композиции, начиная с 1
+}
+
+// Track — композиция альбома с названиями группы и песни
+type Track struct {
+    Position int    `json:"position" example:"1"`
+    SongID   uint   `json:"song_id" example:"1"`
+    Group    string `json:"group" example:"Muse"`
+    Song     string `json:"song" example:"Take a Bow"`
+}
+
+// SongAlbum — альбом, на котором вышла песня, с номером композиции
+type SongAlbum struct {
+    AlbumID     uint        `json:"album_id" example:"1"`
+    Title       string      `json:"title" example:"Black Holes and Revelations"`
+    Type        AlbumType   `json:"type" example:"album"`
+    ReleaseDate ReleaseDate `gorm:"embedded;embeddedPrefix:release_" json:"release_date" swaggertype:"string" example:"2006-07-03"`
+    Position    int         `json:"position" example:"5"`
+}
+
+// AlbumRequest используется при добавлении альбома
+type AlbumRequest struct {
+    Title       string      `json:"title" binding:"required" example:"Black Holes and Revelations"`
+    ArtistID    *uint       `json:"artist_id" example:"1"`
+    ReleaseDate ReleaseDate `json:"release_date" swaggertype:"string" example:"2006-07-03"`
+    Type        AlbumType   `json:"type" binding:"required,oneof=album ep single compilation" example:"album"`
+    // ID песен в порядке следования
+    SongIDs []uint `json:"song_ids" example:"1,2"`
+}
+
+// TracksRequest задаёт новый порядок композиций альбома
+type TracksRequest struct {
+    SongIDs []uint `json:"song_ids" binding:"required" example:"2,1"`
+}
+
+// AlbumList представляет страницу списка альбомов
+type AlbumList struct {
+    Albums     []Album `json:"albums"`
+    Total      int64   `json:"total"`
+    Page       int     `json:"page"`
+    Limit      int     `json:"limit"`
+    TotalPages int     `json:"total_pages"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// RepeatedLine — строка, которая встречается в тексте песни несколько раз
+type RepeatedLine struct {
+    Text  string `json:"text" example:"Ooh baby, don't you know I suffer?"`
+    Count int    `json:"count" example:"4"`
+}
+
+// RhymeScheme — схема рифмовки части песни: строки с одной буквой рифмуются между собой
+type RhymeScheme struct {
+    Index  int         `json:"index" example:"1"` // Номер части в песне, как в GET /songs/{id}/verses
+    Type   SectionType `json:"type" example:"verse"`
+    Label  string      `json:"label" example:"Verse 1"`
+    Scheme string      `json:"scheme" example:"ABAB"`
+}
+
+// LyricsAnalysis — статистика текста песни
+type LyricsAnalysis struct {
+    SongID             uint                `json:"song_id"`
+    Sections           int                 `json:"sections" example:"6"`
+    SectionTypes       map[SectionType]int `json:"section_types"` // Количество частей каждого типа
+    Lines              int                 `json:"lines" example:"24"`
+    Words              int                 `json:"words" example:"142"`
+    UniqueWords        int                 `json:"unique_words" example:"67"`
+    UniqueWordRatio    float64             `json:"unique_word_ratio" example:"0.47"`
+    Syllables          int                 `json:"syllables" example:"198"`
+    RepeatedLines      []RepeatedLine      `json:"repeated_lines"`
+    RhymeSchemes       []RhymeScheme       `json:"rhyme_schemes"`
+    ReadingTimeSeconds float64             `json:"reading_time_seconds" example:"42.6"`
+    SingingTimeSeconds float64             `json:"singing_time_seconds" example:"99"`
+}
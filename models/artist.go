@@ -0,0 +1,92 @@
+package models
+
+import (
+    "database/sql/driver"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// StringList — список строк, хранящийся в колонке JSONB
+type StringList []string
+
+func (l StringList) MarshalJSON() ([]byte, error) {
+    if l == nil {
+        return []byte("[]"), nil
+    }
+    return json.Marshal([]string(l))
+}
+
+func (l StringList) Value() (driver.Value, error) {
+    data, err := l.MarshalJSON()
+    return string(data), err
+}
+
+func (l *StringList) Scan(value interface{}) error {
+    var data []byte
+    switch v := value.(type) {
+    case nil:
+        *l = nil
+        return nil
+    case []byte:
+        data = v
+    case string:
+        data = []byte(v)
+    default:
+        return fmt.Errorf("unsupported type %T for string list", value)
+    }
+    return json.Unmarshal(data, (*[]string)(l))
+}
+
+// Artist — исполнитель: группа или сольный артист
+type Artist struct {
+    ID         uint       `gorm:"primaryKey" json:"id"`
+    CreatedAt  time.Time  `json:"created_at"`
+    UpdatedAt  time.Time  `json:"updated_at"`
+    Name       string     `json:"name" example:"Muse"` // Каноническое название; под ним песни исполнителя возвращаются в поле group
+    Aliases    StringList `gorm:"type:jsonb" json:"aliases" swaggertype:"array,string" example:"Rocket Baby Dolls"`
+    Country    string     `json:"country,omitempty" example:"GB"` // Код страны ISO 3166-1 alpha-2
+    FormedYear *int       `json:"formed_year,omitempty" example:"1994"`
+    // Ключи поиска названия и псевдонимов (см. ArtistKey), заполняются при сохранении
+    NameKey    string     `gorm:"uniqueIndex:idx_artists_name_key" json:"-"`
+    AliasKeys  StringList `gorm:"type:jsonb" json:"-"`
+}
+
+// ArtistKey возвращает ключ, по которому названия и псевдонимы исполнителя считаются одинаковыми (см. NameKey)
+func ArtistKey(name string) string {
+    return NameKey(name)
+}
+
+// UpdateDerivedFields пересчитывает ключи поиска названия и псевдонимов
+func (a *Artist) UpdateDerivedFields() {
+    a.NameKey = ArtistKey(a.Name)
+    a.AliasKeys = make(StringList, 0, len(a.Aliases))
+    for _, alias := range a.Aliases {
+        a.AliasKeys = append(a.AliasKeys, ArtistKey(alias))
+    }
+}
+
+// BeforeSave обновляет ключи поиска перед каждой записью исполнителя в базу данных
+func (a *Artist) BeforeSave(tx *gorm.DB) error {
+    a.UpdateDerivedFields()
+    return nil
+}
+
+// ArtistRequest используется при добавлении и изменении исполнителя
+type ArtistRequest struct {
+    Name       string   `json:"name" binding:"required" example:"Muse"`
+    Aliases    []string `json:"aliases" example:"Rocket Baby Dolls"`
+    Country    string   `json:"country" example:"GB"`
+    FormedYear *int     `json:"formed_year" example:"1994"`
+}
+
+// ArtistList представляет страницу списка исполнителей
+type ArtistList struct {
+    Artists    []Artist `json:"artists"`
+    Total      int64    `json:"total"`
+    Page       int      `json:"page"`
+    Limit      int      `json:"limit"`
+    TotalPages int      `json:"total_pages"`
+}
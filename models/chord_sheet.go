@@ -0,0 +1,11 @@
+package models
+
+// ChordSheet — сведения о загруженном аккордовом листе ChordPro
+type ChordSheet struct {
+    SongID uint     `json:"song_id"`
+    Title  string   `json:"title,omitempty" example:"Supermassive Black Hole"`
+    Artist string   `json:"artist,omitempty" example:"Muse"`
+    Key    string   `json:"key,omitempty" example:"Em"` // Тональность по звучанию
+    Capo   int      `json:"capo,omitempty" example:"2"` // Лад каподастра, для которого записаны аккорды
+    Chords []string `json:"chords" example:"Em,G,D,A"`  // Использованные аккорды в порядке первого появления
+}
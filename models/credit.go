@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// CreditRole — роль участника в создании песни
+type CreditRole string
+
+const (
+    CreditComposer CreditRole = "composer" // Автор музыки
+    CreditLyricist CreditRole = "lyricist" // Автор текста
+    CreditProducer CreditRole = "producer" // Продюсер
+    CreditFeatured CreditRole = "featured" // Приглашённый исполнитель
+)
+
+// SongCredit — участие исполнителя (человека или группы) в песне в одной из ролей.
+// Один исполнитель может участвовать в песне в нескольких ролях.
+type SongCredit struct {
+    SongID    uint       `gorm:"primaryKey" json:"song_id"`
+    ArtistID  uint       `gorm:"primaryKey;index:idx_song_credits_artist_id" json:"artist_id"`
+    Role      CreditRole `gorm:"primaryKey" json:"role" example:"featured"`
+    CreatedAt time.Time  `json:"created_at"`
+    // Название исполнителя; только для чтения
+    Name string `gorm:"->" json:"name" example:"Bob"`
+}
+
+// TableName задаёт имя таблицы участников
+func (SongCredit) TableName() string {
+    return "song_credits"
+}
+
+// CreditRequest используется при добавлении участника песни. Исполнитель ищется по названию или псевдониму
+// и создаётся, если не найден.
+type CreditRequest struct {
+    Name string     `json:"name" binding:"required" example:"Matt Bellamy"`
+    Role CreditRole `json:"role" binding:"required,oneof=composer lyricist producer featured" example:"composer"`
+}
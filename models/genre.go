@@ -0,0 +1,96 @@
+package models
+
+import (
+    "sort"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// MaxTagLength ограничивает длину тега в символах
+const MaxTagLength = 50
+
+// Genre — жанр в дереве жанров: у жанра может быть родительский жанр (Rock > Alternative Rock)
+type Genre struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+    Name      string    `json:"name" example:"Alternative Rock"`
+    ParentID  *uint     `gorm:"index:idx_genres_parent_id" json:"parent_id" example:"1"`
+    // Ключ названия: названия жанров сравниваются без учёта регистра, пунктуации и транслитерации (см. NameKey)
+    NameKey string `gorm:"uniqueIndex:idx_genres_name_key" json:"-"`
+    // Дочерние жанры; заполняются при выводе дерева жанров
+    Children []Genre `gorm:"-" json:"children,omitempty"`
+}
+
+// BeforeSave обновляет ключ названия перед каждой записью жанра в базу данных
+func (g *Genre) BeforeSave(tx *gorm.DB) error {
+    g.NameKey = NameKey(g.Name)
+    return nil
+}
+
+// SongGenre связывает песню с жанром
+type SongGenre struct {
+    SongID  uint `gorm:"primaryKey"`
+    GenreID uint `gorm:"primaryKey;index:idx_song_genres_genre_id"`
+}
+
+// SongTag — тег песни в нормализованной записи (см. NormalizeTag)
+type SongTag struct {
+    SongID uint   `gorm:"primaryKey"`
+    Tag    string `gorm:"primaryKey;index:idx_song_tags_tag"`
+}
+
+// GenreRequest используется при добавлении и изменении жанра
+type GenreRequest struct {
+    Name     string `json:"name" binding:"required" example:"Alternative Rock"`
+    ParentID *uint  `json:"parent_id" example:"1"`
+}
+
+// SongGenresRequest задаёт жанры песни
+type SongGenresRequest struct {
+    GenreIDs []uint `json:"genre_ids" binding:"required" example:"2"`
+}
+
+// TagsRequest содержит теги, добавляемые к песне
+type TagsRequest struct {
+    Tags []string `json:"tags" binding:"required" example:"road trip,summer"`
+}
+
+// TagCount — тег и количество песен с ним
+type TagCount struct {
+    Tag   string `json:"tag" example:"summer"`
+    Count int64  `json:"count" example:"12"`
+}
+
+// GenreTree собирает дерево из списка жанров; жанры одного уровня упорядочиваются по названию
+func GenreTree(genres []Genre) []Genre {
+    children := make(map[uint][]Genre)
+    var roots []Genre
+    for _, genre := range genres {
+        if genre.ParentID == nil {
+            roots = append(roots, genre)
+        } else {
+            children[*genre.ParentID] = append(children[*genre.ParentID], genre)
+        }
+    }
+    var build func(level []Genre) []Genre
+    build = func(level []Genre) []Genre {
+        sort.Slice(level, func(i, j int) bool { return level[i].Name < level[j].Name })
+        for i := range level {
+            level[i].Children = build(children[level[i].ID])
+        }
+        return level
+    }
+    if roots == nil {
+        return []Genre{}
+    }
+    return build(roots)
+}
+
+// NormalizeTag приводит тег к единой записи: без "#" в начале, в нижнем регистре, с одиночными пробелами
+func NormalizeTag(tag string) string {
+    tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+    return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
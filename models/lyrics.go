@@ -0,0 +1,302 @@
+package models
+
+import (
+    "database/sql/driver"
+    "encoding/json"
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// SectionType — тип части песни
+type SectionType string
+
+const (
+    SectionVerse     SectionType = "verse"
+    SectionPreChorus SectionType = "pre-chorus"
+    SectionChorus    SectionType = "chorus"
+    SectionBridge    SectionType = "bridge"
+    SectionIntro     SectionType = "intro"
+    SectionOutro     SectionType = "outro"
+    SectionHook      SectionType = "hook"
+    SectionOther     SectionType = "other"
+)
+
+// sectionKeywords определяет тип части по началу метки. Pre-chorus проверяется раньше chorus.
+var sectionKeywords = []struct {
+    prefix  string
+    section SectionType
+}{
+    {"pre-chorus", SectionPreChorus}, {"pre chorus", SectionPreChorus}, {"prechorus", SectionPreChorus}, {"предприпев", SectionPreChorus},
+    {"verse", SectionVerse}, {"куплет", SectionVerse},
+    {"chorus", SectionChorus}, {"refrain", SectionChorus}, {"припев", SectionChorus},
+    {"bridge", SectionBridge}, {"бридж", SectionBridge},
+    {"intro", SectionIntro}, {"вступление", SectionIntro},
+    {"outro", SectionOutro}, {"концовка", SectionOutro}, {"кода", SectionOutro},
+    {"hook", SectionHook},
+}
+
+// sectionHeader распознаёт строку-метку вида [Verse 1] или [Припев x2].
+// Метка в круглых скобках, например (Chorus), учитывается, только если её тип известен:
+// иначе это часть текста вроде "(Ooh)".
+var sectionHeader = regexp.MustCompile(`^\s*([\[(])\s*([^\])]+?)\s*[\])]\s*$`)
+
+var (
+    labelRepeat = regexp.MustCompile(`(?i)\s*[:,]?\s*[x×х]\s*\d+\s*$`)
+    labelNumber = regexp.MustCompile(`(\d+)\s*$`)
+)
+
+// LyricSection — часть песни (куплет, припев и т. п.) со строками текста
+type LyricSection struct {
+    Type   SectionType `json:"type" example:"chorus"`
+    Label  string      `json:"label" example:"Chorus"`
+    Number int         `json:"number,omitempty"` // Номер куплета и т. п., если части этого типа нумеруются
+    Lines  []string    `json:"lines"`
+    Repeat bool        `json:"repeat,omitempty"` // Часть повторяет ранее встречавшуюся
+}
+
+// Text возвращает текст части песни
+func (s LyricSection) Text() string {
+    return strings.Join(s.Lines, "\n")
+}
+
+// LyricSections — структурированный текст песни; хранится в колонке songs.sections (JSONB)
+type LyricSections []LyricSection
+
+func (s LyricSections) MarshalJSON() ([]byte, error) {
+    if s == nil {
+        return []byte("[]"), nil
+    }
+    return json.Marshal([]LyricSection(s))
+}
+
+func (s LyricSections) Value() (driver.Value, error) {
+    data, err := s.MarshalJSON()
+    return string(data), err
+}
+
+func (s *LyricSections) Scan(value interface{}) error {
+    var data []byte
+    switch v := value.(type) {
+    case nil:
+        *s = nil
+        return nil
+    case []byte:
+        data = v
+    case string:
+        data = []byte(v)
+    default:
+        return fmt.Errorf("unsupported type %T for lyric sections", value)
+    }
+    return json.Unmarshal(data, (*[]LyricSection)(s))
+}
+
+// rawBlock — блок строк до пустой строки или следующей метки
+type rawBlock struct {
+    header    string
+    hasHeader bool
+    lines     []string
+}
+
+// ParseSections разбирает текст песни на части. Части разделяются пустыми строками или метками вида [Verse 1],
+// [Chorus], [Припев]; переводы строк CRLF и CR поддерживаются. Метка без текста (например, повторный [Chorus])
+// повторяет ранее встречавшуюся часть с той же меткой. Неразмеченные блоки, встречающиеся в тексте несколько раз,
+// считаются припевом, остальные — куплетами.
+func ParseSections(text string) LyricSections {
+    blocks := splitBlocks(text)
+
+    // Повторяющиеся неразмеченные блоки считаются припевом
+    occurrences := make(map[string]int)
+    for _, block := range blocks {
+        if !block.hasHeader && len(block.lines) > 0 {
+            occurrences[blockKey(block.lines)]++
+        }
+    }
+
+    sections := make(LyricSections, 0, len(blocks))
+    counters := make(map[SectionType]int)
+    seen := make(map[string]int) // Содержимое части -> индекс первого вхождения
+    for _, block := range blocks {
+        var section LyricSection
+        if block.hasHeader {
+            section = labeledSection(block.header)
+            if len(block.lines) == 0 {
+                previous, ok := findSection(sections, section)
+                if !ok {
+                    continue
+                }
+                section.Lines, section.Number, section.Repeat = previous.Lines, previous.Number, true
+                sections = append(sections, section)
+                continue
+            }
+        } else {
+            if len(block.lines) == 0 {
+                continue
+            }
+            section.Type = SectionVerse
+            if occurrences[blockKey(block.lines)] > 1 {
+                section.Type = SectionChorus
+            }
+        }
+        section.Lines = block.lines
+
+        key := blockKey(block.lines)
+        if first, ok := seen[key]; ok {
+            // Повтор уже встречавшейся части сохраняет её тип, метку и номер
+            original := sections[first]
+            if !block.hasHeader {
+                section.Type, section.Label = original.Type, original.Label
+            }
+            section.Number, section.Repeat = original.Number, true
+        } else {
+            seen[key] = len(sections)
+            if section.Number == 0 && section.Type == SectionVerse {
+                counters[section.Type]++
+                section.Number = counters[section.Type]
+            } else if section.Number > counters[section.Type] {
+                counters[section.Type] = section.Number
+            }
+        }
+        if section.Label == "" {
+            section.Label = defaultLabel(section.Type, section.Number)
+        }
+        sections = append(sections, section)
+    }
+    return sections
+}
+
+// splitBlocks делит текст на блоки по пустым строкам и строкам-меткам
+func splitBlocks(text string) []rawBlock {
+    text = strings.ReplaceAll(text, "\r\n", "\n")
+    text = strings.ReplaceAll(text, "\r", "\n")
+
+    var blocks []rawBlock
+    current := rawBlock{}
+    flush := func() {
+        if current.hasHeader || len(current.lines) > 0 {
+            blocks = append(blocks, current)
+        }
+        current = rawBlock{}
+    }
+    for _, line := range strings.Split(text, "\n") {
+        line = strings.TrimRight(line, " \t")
+        if strings.TrimSpace(line) == "" {
+            flush()
+            continue
+        }
+        if header, ok := parseHeader(line); ok {
+            flush()
+            current = rawBlock{header: header, hasHeader: true}
+            continue
+        }
+        current.lines = append(current.lines, line)
+    }
+    flush()
+    return blocks
+}
+
+// parseHeader распознаёт строку-метку и возвращает текст метки
+func parseHeader(line string) (string, bool) {
+    match := sectionHeader.FindStringSubmatch(line)
+    if match == nil {
+        return "", false
+    }
+    if match[1] == "(" && sectionTypeOf(match[2]) == SectionOther {
+        return "", false
+    }
+    return match[2], true
+}
+
+// labeledSection создаёт часть по метке: определяет тип и номер, отбрасывает пометку повтора "x2"
+func labeledSection(header string) LyricSection {
+    label := strings.TrimSuffix(strings.TrimSpace(labelRepeat.ReplaceAllString(header, "")), ":")
+    section := LyricSection{Type: sectionTypeOf(label), Label: label}
+    if match := labelNumber.FindStringSubmatch(label); match != nil {
+        section.Number, _ = strconv.Atoi(match[1])
+    }
+    return section
+}
+
+func sectionTypeOf(label string) SectionType {
+    label = strings.ToLower(strings.TrimSpace(label))
+    for _, keyword := range sectionKeywords {
+        if strings.HasPrefix(label, keyword.prefix) {
+            return keyword.section
+        }
+    }
+    return SectionOther
+}
+
+// findSection ищет последнюю часть с той же меткой, а если её нет — с тем же типом и номером
+func findSection(sections LyricSections, target LyricSection) (LyricSection, bool) {
+    for i := len(sections) - 1; i >= 0; i-- {
+        if strings.EqualFold(sections[i].Label, target.Label) {
+            return sections[i], true
+        }
+    }
+    for i := len(sections) - 1; i >= 0; i-- {
+        if sections[i].Type == target.Type && (target.Number == 0 || sections[i].Number == target.Number) {
+            return sections[i], true
+        }
+    }
+    return LyricSection{}, false
+}
+
+// blockKey сравнивает блоки без учёта регистра и пробелов по краям строк
+func blockKey(lines []string) string {
+    normalized := make([]string, len(lines))
+    for i, line := range lines {
+        normalized[i] = strings.ToLower(strings.TrimSpace(line))
+    }
+    return strings.Join(normalized, "\n")
+}
+
+func defaultLabel(section SectionType, number int) string {
+    var label string
+    switch section {
+    case SectionPreChorus:
+        label = "Pre-Chorus"
+    case SectionOther:
+        label = "Section"
+    default:
+        label = strings.ToUpper(string(section[:1])) + string(section[1:])
+    }
+    if number > 0 {
+        label += " " + strconv.Itoa(number)
+    }
+    return label
+}
+
+// ParseSectionTypes разбирает список типов частей через запятую
+func ParseSectionTypes(value string) ([]SectionType, error) {
+    var types []SectionType
+    for _, part := range strings.Split(value, ",") {
+        part = strings.ToLower(strings.TrimSpace(part))
+        if part == "" {
+            continue
+        }
+        switch section := SectionType(part); section {
+        case SectionVerse, SectionPreChorus, SectionChorus, SectionBridge, SectionIntro, SectionOutro, SectionHook, SectionOther:
+            types = append(types, section)
+        default:
+            return nil, fmt.Errorf("unknown section type %q", part)
+        }
+    }
+    return types, nil
+}
+
+// VerseSection — часть песни с её номером в песне
+type VerseSection struct {
+    Index int `json:"index" example:"2"`
+    LyricSection
+}
+
+// LyricLine — строка текста песни с указанием части, к которой она относится
+type LyricLine struct {
+    Section int         `json:"section" example:"2"` // Номер части в песне
+    Type    SectionType `json:"type" example:"chorus"`
+    Label   string      `json:"label" example:"Chorus"`
+    Line    int         `json:"line" example:"1"` // Номер строки в части
+    Text    string      `json:"text"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestParseSections(t *testing.T) {
+    tests := []struct {
+        name string
+        text string
+        want LyricSections
+    }{
+        {
+            name: "empty text",
+            text: "",
+            want: LyricSections{},
+        },
+        {
+            name: "labels and repeated chorus",
+            text: "[Verse 1]\nA\nB\n\n[Chorus]\nC\nD\n\n[Verse 2]\nE\n\n[Chorus]",
+            want: LyricSections{
+                {Type: SectionVerse, Label: "Verse 1", Number: 1, Lines: []string{"A", "B"}},
+                {Type: SectionChorus, Label: "Chorus", Lines: []string{"C", "D"}},
+                {Type: SectionVerse, Label: "Verse 2", Number: 2, Lines: []string{"E"}},
+                {Type: SectionChorus, Label: "Chorus", Lines: []string{"C", "D"}, Repeat: true},
+            },
+        },
+        {
+            name: "repeated unlabeled block is a chorus",
+            text: "A\nB\n\nC\nD\n\nE\nF\n\nC\nD",
+            want: LyricSections{
+                {Type: SectionVerse, Label: "Verse 1", Number: 1, Lines: []string{"A", "B"}},
+                {Type: SectionChorus, Label: "Chorus", Lines: []string{"C", "D"}},
+                {Type: SectionVerse, Label: "Verse 2", Number: 2, Lines: []string{"E", "F"}},
+                {Type: SectionChorus, Label: "Chorus", Lines: []string{"C", "D"}, Repeat: true},
+            },
+        },
+        {
+            name: "CRLF and parenthesized text",
+            text: "Line one\r\nLine two\r\n\r\n(Ooh)\r\nthree",
+            want: LyricSections{
+                {Type: SectionVerse, Label: "Verse 1", Number: 1, Lines: []string{"Line one", "Line two"}},
+                {Type: SectionVerse, Label: "Verse 2", Number: 2, Lines: []string{"(Ooh)", "three"}},
+            },
+        },
+        {
+            name: "russian label with repeat count and parenthesized label",
+            text: "[Припев x2]\nla\n\n(Chorus)\nmore",
+            want: LyricSections{
+                {Type: SectionChorus, Label: "Припев", Lines: []string{"la"}},
+                {Type: SectionChorus, Label: "Chorus", Lines: []string{"more"}},
+            },
+        },
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := ParseSections(tt.text); !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("ParseSections() = %+v, want %+v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestParseSectionTypes(t *testing.T) {
+    got, err := ParseSectionTypes("verse, chorus")
+    if err != nil {
+        t.Fatalf("ParseSectionTypes() error = %v", err)
+    }
+    if want := []SectionType{SectionVerse, SectionChorus}; !reflect.DeepEqual(got, want) {
+        t.Errorf("ParseSectionTypes() = %v, want %v", got, want)
+    }
+    if _, err := ParseSectionTypes("verse,solo"); err == nil {
+        t.Error("ParseSectionTypes(\"verse,solo\") error = nil, want an error")
+    }
+}
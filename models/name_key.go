@@ -0,0 +1,15 @@
+package models
+
+import (
+    "go-tunes/translit"
+    "strings"
+)
+
+// NameKey возвращает ключ, по которому названия считаются одинаковыми: без учёта регистра, пунктуации
+// и транслитерации ("Muse", "MUSE", "Кино" и "Kino"). Для названий без букв и цифр — название в нижнем регистре.
+func NameKey(name string) string {
+    if key := translit.Key(name); key != "" {
+        return key
+    }
+    return strings.ToLower(strings.TrimSpace(name))
+}
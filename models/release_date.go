@@ -0,0 +1,134 @@
+package models
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+)
+
+// DatePrecision показывает, с какой точностью известна дата релиза
+type DatePrecision string
+
+const (
+    PrecisionDay   DatePrecision = "day"
+    PrecisionMonth DatePrecision = "month"
+    PrecisionYear  DatePrecision = "year"
+)
+
+// releaseDateLayouts перечисляет поддерживаемые форматы ввода от самого точного к наименее точному
+var releaseDateLayouts = []struct {
+    layout    string
+    precision DatePrecision
+}{
+    {"2006-01-02", PrecisionDay},
+    {"02.01.2006", PrecisionDay},
+    {"2.1.2006", PrecisionDay},
+    {"2006/01/02", PrecisionDay},
+    {"2006-01", PrecisionMonth},
+    {"01.2006", PrecisionMonth},
+    {"1.2006", PrecisionMonth},
+    {"2006/01", PrecisionMonth},
+    {"2006", PrecisionYear},
+}
+
+// ReleaseDate — нормализованная дата релиза с признаком точности.
+// Неточные даты (год, год и месяц) хранятся как начало периода.
+// В JSON дата представляется строкой ISO 8601 соответствующей точности: "2006-07-16", "2006-07" или "2006".
+type ReleaseDate struct {
+    Date      *time.Time    `gorm:"column:date;type:date"`
+    Precision DatePrecision `gorm:"column:date_precision;type:varchar(5)"`
+}
+
+// ParseReleaseDate разбирает дату релиза в форматах DD.MM.YYYY, YYYY-MM-DD (в том числе RFC 3339),
+// YYYY-MM, MM.YYYY и YYYY. Пустая строка означает отсутствие даты.
+func ParseReleaseDate(value string) (ReleaseDate, error) {
+    value = strings.TrimSpace(value)
+    if value == "" {
+        return ReleaseDate{}, nil
+    }
+
+    for _, format := range releaseDateLayouts {
+        if date, err := time.Parse(format.layout, value); err == nil {
+            return NewReleaseDate(date, format.precision), nil
+        }
+    }
+    if date, err := time.Parse(time.RFC3339, value); err == nil {
+        return NewReleaseDate(date, PrecisionDay), nil
+    }
+    return ReleaseDate{}, fmt.Errorf("invalid release date %q: expected DD.MM.YYYY, YYYY-MM-DD, YYYY-MM, MM.YYYY or YYYY", value)
+}
+
+// NewReleaseDate усекает дату до начала периода указанной точности
+func NewReleaseDate(date time.Time, precision DatePrecision) ReleaseDate {
+    year, month, day := date.Date()
+    switch precision {
+    case PrecisionYear:
+        month, day = time.January, 1
+    case PrecisionMonth:
+        day = 1
+    default:
+        precision = PrecisionDay
+    }
+    normalized := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+    return ReleaseDate{Date: &normalized, Precision: precision}
+}
+
+// IsZero сообщает, что дата релиза неизвестна
+func (d ReleaseDate) IsZero() bool {
+    return d.Date == nil
+}
+
+// End возвращает начало следующего периода: для даты с точностью до года — 1 января следующего года и т. д.
+func (d ReleaseDate) End() time.Time {
+    if d.Date == nil {
+        return time.Time{}
+    }
+    switch d.Precision {
+    case PrecisionYear:
+        return d.Date.AddDate(1, 0, 0)
+    case PrecisionMonth:
+        return d.Date.AddDate(0, 1, 0)
+    default:
+        return d.Date.AddDate(0, 0, 1)
+    }
+}
+
+// String возвращает дату в формате ISO 8601 соответствующей точности
+func (d ReleaseDate) String() string {
+    if d.Date == nil {
+        return ""
+    }
+    switch d.Precision {
+    case PrecisionYear:
+        return d.Date.Format("2006")
+    case PrecisionMonth:
+        return d.Date.Format("2006-01")
+    default:
+        return d.Date.Format("2006-01-02")
+    }
+}
+
+func (d ReleaseDate) MarshalJSON() ([]byte, error) {
+    if d.Date == nil {
+        return []byte("null"), nil
+    }
+    return json.Marshal(d.String())
+}
+
+func (d *ReleaseDate) UnmarshalJSON(data []byte) error {
+    if string(data) == "null" {
+        *d = ReleaseDate{}
+        return nil
+    }
+    var value string
+    if err := json.Unmarshal(data, &value); err != nil {
+        return fmt.Errorf("release date must be a string: %w", err)
+    }
+    parsed, err := ParseReleaseDate(value)
+    if err != nil {
+        return err
+    }
+    *d = parsed
+    return nil
+}
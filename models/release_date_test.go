@@ -0,0 +1,58 @@
+package models
+
+import (
+    "encoding/json"
+    "testing"
+    "time"
+)
+
+func TestParseReleaseDate(t *testing.T) {
+    tests := []struct {
+        value   string
+        want    string
+        end     string
+        wantErr bool
+    }{
+        {"16.07.2006", "2006-07-16", "2006-07-17", false},
+        {"2006-07-16", "2006-07-16", "2006-07-17", false},
+        {"2006-07", "2006-07", "2006-08-01", false},
+        {"07.2006", "2006-07", "2006-08-01", false},
+        {"2006", "2006", "2007-01-01", false},
+        {"2006-07-16T10:00:00Z", "2006-07-16", "2006-07-17", false},
+        {"  ", "", "", false},
+        {"", "", "", false},
+        {"16/07/2006", "", "", true},
+        {"someday", "", "", true},
+    }
+    for _, tt := range tests {
+        got, err := ParseReleaseDate(tt.value)
+        if (err != nil) != tt.wantErr {
+            t.Errorf("ParseReleaseDate(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+            continue
+        }
+        if got.String() != tt.want {
+            t.Errorf("ParseReleaseDate(%q) = %q, want %q", tt.value, got.String(), tt.want)
+        }
+        end := ""
+        if got.Date != nil {
+            end = got.End().Format(time.DateOnly)
+        }
+        if end != tt.end {
+            t.Errorf("ParseReleaseDate(%q).End() = %q, want %q", tt.value, end, tt.end)
+        }
+    }
+}
+
+func TestReleaseDateJSON(t *testing.T) {
+    var song SongDetail
+    if err := json.Unmarshal([]byte(`{"release_date":"07.2006"}`), &song); err != nil {
+        t.Fatalf("Unmarshal() error = %v", err)
+    }
+    data, err := json.Marshal(song.ReleaseDate)
+    if err != nil || string(data) != `"2006-07"` {
+        t.Errorf("Marshal() = %s, %v; want \"2006-07\"", data, err)
+    }
+    if err := json.Unmarshal([]byte(`{"release_date":"someday"}`), &song); err == nil {
+        t.Error("Unmarshal() of an invalid date error = nil, want an error")
+    }
+}
@@ -0,0 +1,39 @@
+package models
+
+// VerseMatch описывает часть песни (куплет, припев и т. п.), в которой найдено совпадение.
+// Номер части совпадает с номером страницы GET /songs/{id}/verses при limit=1.
+type VerseMatch struct {
+    Verse   int    `json:"verse" example:"2"`
+    Snippet string `json:"snippet" example:"Glaciers melting in the dead of night, and the <b>superstars</b> sucked into the <b>supermassive</b>"`
+}
+
+// SearchHit — песня, найденная полнотекстовым поиском, с релевантностью и совпавшими куплетами
+type SearchHit struct {
+    Song    Song         `json:"song"`
+    Rank    float64      `json:"rank"`
+    Matches []VerseMatch `json:"matches"`
+}
+
+// SearchResult представляет страницу результатов поиска
+type SearchResult struct {
+    Query      string      `json:"query"`
+    Results    []SearchHit `json:"results"`
+    Total      int64       `json:"total"`
+    Page       int         `json:"page"`
+    Limit      int         `json:"limit"`
+    TotalPages int         `json:"total_pages"`
+}
+
+// AutocompleteSuggestion — подсказка названия группы или песни
+type AutocompleteSuggestion struct {
+    Value      string `json:"value" example:"Muse"`
+    Songs      int    `json:"songs" example:"3"`       // Количество песен с этим названием
+    Popularity int    `json:"popularity" example:"42"` // Количество песен и обращений к ним
+}
+
+// AutocompleteResult представляет ответ на запрос подсказок
+type AutocompleteResult struct {
+    Query       string                   `json:"query"`
+    Field       string                   `json:"field" example:"group"`
+    Suggestions []AutocompleteSuggestion `json:"suggestions"`
+}
@@ -1,7 +1,11 @@
 package models
 
 import (
+    "go-tunes/langdetect"
+    "go-tunes/translit"
     "time"
+
+    "gorm.io/gorm"
 )
 
 // Song представляет песню в базе данных
@@ -9,19 +13,78 @@ type Song struct {
     ID          uint      `gorm:"primaryKey" json:"id"`
     CreatedAt   time.Time `json:"created_at"`
     UpdatedAt   time.Time `json:"updated_at"`
-    DeletedAt   *time.Time `gorm:"index" json:"deleted_at,omitempty"` 
-    Group   string    `json:"group"`      
-    Song    string    `json:"song"`       
-    ReleaseDate string    `json:"release_date"`
+    DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+    // Уникальность пары группа+песня проверяется только среди неудалённых песен
+    Group   string    `gorm:"uniqueIndex:idx_songs_group_song_active,where:deleted_at IS NULL" json:"group"`
+    Song    string    `gorm:"uniqueIndex:idx_songs_group_song_active" json:"song"`
+    // Исполнитель песни; при сохранении находится (или создаётся) по названию группы, а группа приводится к его названию
+    ArtistID    *uint     `gorm:"index:idx_songs_artist_id" json:"artist_id" example:"1"`
+    ReleaseDate ReleaseDate `gorm:"embedded;embeddedPrefix:release_" json:"release_date" swaggertype:"string" example:"2006-07-16"`
     Text        string    `json:"text"`
+    // Язык текста (код ISO 639-1), определяется автоматически при сохранении; пустая строка — язык не определён
+    Language    string    `gorm:"index:idx_songs_language" json:"language" example:"ru"`
+    // Текст песни, разобранный на части (куплеты, припевы и т. п.); пересчитывается при сохранении
+    Sections    LyricSections `gorm:"type:jsonb" json:"sections"`
     Link        string    `json:"link"`
+    // Длительность, ISRC, темп, тональность и пометка о ненормативной лексике
+    TrackMetadata `gorm:"embedded"`
+    // Текст с синхронизацией по времени в формате LRC; загружается и выгружается отдельными запросами
+    SyncedLyrics string   `gorm:"type:text" json:"-"`
+    // Аккордовый лист в формате ChordPro; загружается и выводится отдельными запросами
+    ChordPro    string    `gorm:"column:chordpro;type:text" json:"-"`
+    // Ключи поиска по названиям с учётом транслитерации (см. пакет translit), заполняются при сохранении
+    GroupKey    string    `json:"-"`
+    SongKey     string    `json:"-"`
+    // Альбомы, на которых вышла песня; заполняются в списках песен
+    Albums      []SongAlbum `gorm:"-" json:"albums,omitempty"`
+}
+
+// UpdateDerivedFields пересчитывает поля, производные от названий и текста: ключи поиска, части песни и язык текста
+func (s *Song) UpdateDerivedFields() {
+    s.GroupKey = translit.Key(s.Group)
+    s.SongKey = translit.Key(s.Song)
+    s.Sections = ParseSections(s.Text)
+    s.Language = langdetect.Detect(s.Text).Language
+}
+
+// BeforeSave обновляет производные поля перед каждой записью песни в базу данных
+func (s *Song) BeforeSave(tx *gorm.DB) error {
+    s.UpdateDerivedFields()
+    return nil
 }
 
 // SongDetail представляет детальную информацию о песне
 type SongDetail struct {
-    ReleaseDate string `json:"release_date"`
+    ReleaseDate ReleaseDate `json:"release_date" swaggertype:"string" example:"2006-07-16"`
     Text        string `json:"text"`
     Link        string `json:"link"`
+    TrackMetadata
+}
+
+// SongList представляет страницу списка песен.
+// При навигации по курсору номер страницы не передаётся.
+type SongList struct {
+    Songs      []Song `json:"songs"`
+    Total      int64  `json:"total"`
+    Page       int    `json:"page,omitempty"`
+    Limit      int    `json:"limit"`
+    TotalPages int    `json:"total_pages"`
+    NextCursor string `json:"next_cursor,omitempty"`
+    PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// SongSuggestion — похожая песня, предлагаемая вместо ненайденной
+type SongSuggestion struct {
+    ID    uint    `json:"id"`
+    Group string  `json:"group"`
+    Song  string  `json:"song"`
+    Score float64 `json:"score" example:"0.92"`
+}
+
+// SongNotFound — ответ 404 с подсказками "возможно, вы имели в виду"
+type SongNotFound struct {
+    Error       string           `json:"error" example:"song not found"`
+    Suggestions []SongSuggestion `json:"suggestions"`
 }
 
 // NewSongRequest используется при добавлении новой песни
@@ -0,0 +1,50 @@
+package models
+
+// SyncedWord — слово строки с собственной меткой времени (расширенный формат LRC)
+type SyncedWord struct {
+    TimeMs int64  `json:"time_ms" example:"12500"`
+    Text   string `json:"text" example:"baby"`
+}
+
+// SyncedLine — строка текста с меткой времени начала
+type SyncedLine struct {
+    TimeMs int64        `json:"time_ms" example:"12000"`
+    Text   string       `json:"text" example:"Ooh baby, don't you know I suffer?"`
+    Words  []SyncedWord `json:"words,omitempty"`
+}
+
+// LyricsTag — тег метаданных LRC, например [ar:Muse]
+type LyricsTag struct {
+    Key   string `json:"key" example:"ar"`
+    Value string `json:"value" example:"Muse"`
+}
+
+// SyncedLyrics — текст песни с синхронизацией по времени.
+// Времена строк и слов указаны без учёта смещения OffsetMs: положительное смещение
+// означает, что строки нужно показывать раньше на указанное количество миллисекунд.
+type SyncedLyrics struct {
+    Tags     []LyricsTag  `json:"tags"`
+    OffsetMs int64        `json:"offset_ms"`
+    Lines    []SyncedLine `json:"lines"`
+}
+
+// LyricsProblem описывает ошибку в строке загруженного файла с текстом песни
+type LyricsProblem struct {
+    Line    int    `json:"line" example:"3"` // Номер строки файла; 0 — ошибка относится к файлу целиком
+    Message string `json:"message" example:"invalid timestamp [00:75.00]: seconds must be less than 60"`
+}
+
+// InvalidLyrics — ответ 400 со списком ошибок в загруженном файле
+type InvalidLyrics struct {
+    Error    string          `json:"error" example:"invalid LRC"`
+    Problems []LyricsProblem `json:"problems"`
+}
+
+// LyricsPosition — строки, соответствующие позиции воспроизведения
+type LyricsPosition struct {
+    SongID     uint        `json:"song_id"`
+    PositionMs int64       `json:"position_ms"`
+    Current    *SyncedLine `json:"current"`              // Текущая строка; null, если первая строка ещё не началась
+    Next       *SyncedLine `json:"next"`                 // Следующая строка; null, если текущая строка последняя
+    WordIndex  *int        `json:"word_index,omitempty"` // Номер текущего слова в строке (с 0), если у строки есть метки слов
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "strings"
+
+    "golang.org/x/text/language"
+)
+
+const (
+    // MaxDurationSeconds ограничивает длительность песни (сутки)
+    MaxDurationSeconds = 24 * 60 * 60
+    // MaxBPM ограничивает темп песни
+    MaxBPM = 400
+)
+
+// Mode — лад тональности
+type Mode string
+
+const (
+    ModeMajor Mode = "major"
+    ModeMinor Mode = "minor"
+)
+
+// isrcAgencyPrefixes — префиксы ISRC, которые выдаёт агентство ISRC помимо кодов стран ISO 3166-1
+var isrcAgencyPrefixes = map[string]bool{"QM": true, "QN": true, "QT": true, "QZ": true, "CP": true, "DG": true, "ZZ": true}
+
+// TrackMetadata — необязательные сведения о записи песни. Поля приходят из внешнего API обогащения
+// или задаются при изменении песни; отсутствующее значение — null.
+type TrackMetadata struct {
+    DurationSeconds *int `gorm:"column:duration_seconds" json:"duration_seconds" example:"212"`
+    // International Standard Recording Code без дефисов: CC XXX YY NNNNN
+    ISRC *string `gorm:"column:isrc;index:idx_songs_isrc" json:"isrc" example:"GBAHT0500600"`
+    // Темп в ударах в минуту
+    BPM *float64 `gorm:"column:bpm;index:idx_songs_bpm" json:"bpm" example:"120"`
+    // Тоника тональности: C, C#, Db, ..., B
+    Key      *string `gorm:"column:musical_key" json:"key" example:"G"`
+    Mode     *Mode   `gorm:"column:mode" json:"mode" example:"minor"`
+    Explicit *bool   `gorm:"column:explicit" json:"explicit" example:"false"`
+}
+
+// Normalize приводит поля к единой записи: ISRC — без дефисов и пробелов в верхнем регистре, тоника —
+// "C#" или "Db", лад — в нижнем регистре. Некорректные поля очищаются, а описания ошибок возвращаются
+// вместе: данные внешнего API можно сохранить без них, а изменение песни — отклонить.
+func (m *TrackMetadata) Normalize() error {
+    var problems []error
+    if m.DurationSeconds != nil && (*m.DurationSeconds <= 0 || *m.DurationSeconds > MaxDurationSeconds) {
+        problems = append(problems, fmt.Errorf("invalid duration_seconds %d: expected 1 to %d", *m.DurationSeconds, MaxDurationSeconds))
+        m.DurationSeconds = nil
+    }
+    if m.ISRC != nil {
+        if isrc, err := ParseISRC(*m.ISRC); err != nil {
+            problems = append(problems, err)
+            m.ISRC = nil
+        } else {
+            m.ISRC = &isrc
+        }
+    }
+    if m.BPM != nil {
+        if bpm := *m.BPM; math.IsNaN(bpm) || bpm <= 0 || bpm > MaxBPM {
+            problems = append(problems, fmt.Errorf("invalid bpm %g: expected more than 0 and at most %d", bpm, MaxBPM))
+            m.BPM = nil
+        }
+    }
+    if m.Key != nil {
+        if key, err := ParseKey(*m.Key); err != nil {
+            problems = append(problems, err)
+            m.Key = nil
+        } else {
+            m.Key = &key
+        }
+    }
+    if m.Mode != nil {
+        if mode, err := ParseMode(string(*m.Mode)); err != nil {
+            problems = append(problems, err)
+            m.Mode = nil
+        } else {
+            m.Mode = &mode
+        }
+    }
+    return errors.Join(problems...)
+}
+
+// Merge заполняет отсутствующие поля значениями из other
+func (m *TrackMetadata) Merge(other TrackMetadata) {
+    if m.DurationSeconds == nil {
+        m.DurationSeconds = other.DurationSeconds
+    }
+    if m.ISRC == nil {
+        m.ISRC = other.ISRC
+    }
+    if m.BPM == nil {
+        m.BPM = other.BPM
+    }
+    if m.Key == nil {
+        m.Key = other.Key
+    }
+    if m.Mode == nil {
+        m.Mode = other.Mode
+    }
+    if m.Explicit == nil {
+        m.Explicit = other.Explicit
+    }
+}
+
+// Clone возвращает копию, не разделяющую значения полей с исходными сведениями
+func (m TrackMetadata) Clone() TrackMetadata {
+    return TrackMetadata{
+        DurationSeconds: clonePointer(m.DurationSeconds),
+        ISRC:            clonePointer(m.ISRC),
+        BPM:             clonePointer(m.BPM),
+        Key:             clonePointer(m.Key),
+        Mode:            clonePointer(m.Mode),
+        Explicit:        clonePointer(m.Explicit),
+    }
+}
+
+func clonePointer[T any](value *T) *T {
+    if value == nil {
+        return nil
+    }
+    clone := *value
+    return &clone
+}
+
+// ParseISRC проверяет код ISRC и возвращает его без дефисов и пробелов в верхнем регистре.
+// Код состоит из кода страны (ISO 3166-1 alpha-2 или префикса агентства ISRC), трёх букв или цифр
+// кода регистранта, двух цифр года и пяти цифр номера записи: US-RC1-76-07839 или USRC17607839.
+func ParseISRC(value string) (string, error) {
+    isrc := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(value)))
+    invalid := fmt.Errorf("invalid isrc %q: expected CC-XXX-YY-NNNNN", value)
+    if len(isrc) != 12 {
+        return "", invalid
+    }
+    for i, r := range isrc {
+        var ok bool
+        switch {
+        case i < 2:
+            ok = r >= 'A' && r <= 'Z'
+        case i < 5:
+            ok = r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+        default:
+            ok = r >= '0' && r <= '9'
+        }
+        if !ok {
+            return "", invalid
+        }
+    }
+    if country := isrc[:2]; !isrcAgencyPrefixes[country] {
+        if region, err := language.ParseRegion(country); err != nil || !region.IsCountry() {
+            return "", fmt.Errorf("invalid isrc %q: unknown country code %s", value, country)
+        }
+    }
+    return isrc, nil
+}
+
+// ParseKey проверяет тонику тональности и возвращает её в записи "C#" или "Db"
+func ParseKey(value string) (string, error) {
+    key := strings.NewReplacer("♯", "#", "♭", "b").Replace(strings.TrimSpace(value))
+    if key == "" || !strings.ContainsRune("ABCDEFG", rune(strings.ToUpper(key[:1])[0])) {
+        return "", fmt.Errorf("invalid key %q: expected a note from A to G with an optional # or b", value)
+    }
+    accidental := key[1:]
+    if accidental != "" && accidental != "#" && accidental != "b" {
+        return "", fmt.Errorf("invalid key %q: expected a note from A to G with an optional # or b", value)
+    }
+    return strings.ToUpper(key[:1]) + accidental, nil
+}
+
+// ParseMode проверяет лад тональности
+func ParseMode(value string) (Mode, error) {
+    switch mode := Mode(strings.ToLower(strings.TrimSpace(value))); mode {
+    case ModeMajor, ModeMinor:
+        return mode, nil
+    }
+    return "", fmt.Errorf("invalid mode %q: expected major or minor", value)
+}
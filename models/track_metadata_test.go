@@ -0,0 +1,105 @@
+package models
+
+import (
+    "testing"
+)
+
+func TestParseISRC(t *testing.T) {
+    tests := []struct {
+        value   string
+        want    string
+        wantErr bool
+    }{
+        {"USRC17607839", "USRC17607839", false},
+        {"us-rc1-76-07839", "USRC17607839", false},
+        {" GB AHT 05 00600 ", "GBAHT0500600", false},
+        {"QM-ABC-24-00001", "QMABC2400001", false},
+        {"USRC1760783", "", true},   // Слишком короткий
+        {"USRC176078390", "", true}, // Слишком длинный
+        {"U1RC17607839", "", true},  // Цифра в коде страны
+        {"USRC1A607839", "", true}, // Буква в годе
+        {"USRC17607B39", "", true}, // Буква в номере записи
+        {"XXRC17607839", "", true}, // Неизвестная страна
+        {"", "", true},
+    }
+    for _, tt := range tests {
+        got, err := ParseISRC(tt.value)
+        if (err != nil) != tt.wantErr || got != tt.want {
+            t.Errorf("ParseISRC(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+        }
+    }
+}
+
+func TestParseKey(t *testing.T) {
+    tests := []struct {
+        value   string
+        want    string
+        wantErr bool
+    }{
+        {"C", "C", false},
+        {"f#", "F#", false},
+        {"Bb", "Bb", false},
+        {"e♭", "Eb", false},
+        {"G♯", "G#", false},
+        {"H", "", true},
+        {"C##", "", true},
+        {"Am", "", true},
+        {"", "", true},
+    }
+    for _, tt := range tests {
+        got, err := ParseKey(tt.value)
+        if (err != nil) != tt.wantErr || got != tt.want {
+            t.Errorf("ParseKey(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+        }
+    }
+}
+
+func TestParseMode(t *testing.T) {
+    for value, want := range map[string]Mode{"major": ModeMajor, " Minor ": ModeMinor} {
+        if got, err := ParseMode(value); err != nil || got != want {
+            t.Errorf("ParseMode(%q) = %q, %v; want %q", value, got, err, want)
+        }
+    }
+    if _, err := ParseMode("dorian"); err == nil {
+        t.Error("ParseMode(\"dorian\") error = nil, want an error")
+    }
+}
+
+func TestTrackMetadataNormalize(t *testing.T) {
+    duration, bpm, isrc, key, mode := 212, 128.5, "us-rc1-76-07839", "f#", Mode("MINOR")
+    valid := TrackMetadata{DurationSeconds: &duration, ISRC: &isrc, BPM: &bpm, Key: &key, Mode: &mode}
+    if err := valid.Normalize(); err != nil {
+        t.Fatalf("Normalize() error = %v", err)
+    }
+    if *valid.ISRC != "USRC17607839" || *valid.Key != "F#" || *valid.Mode != ModeMinor || *valid.DurationSeconds != 212 || *valid.BPM != 128.5 {
+        t.Errorf("Normalize() = %+v, want normalized values", valid)
+    }
+
+    negative, tooFast, badISRC := -1, 1000.0, "bad"
+    invalid := TrackMetadata{DurationSeconds: &negative, BPM: &tooFast, ISRC: &badISRC, Key: &key}
+    if err := invalid.Normalize(); err == nil {
+        t.Fatal("Normalize() error = nil, want an error")
+    }
+    if invalid.DurationSeconds != nil || invalid.BPM != nil || invalid.ISRC != nil {
+        t.Errorf("Normalize() = %+v, want invalid fields to be cleared", invalid)
+    }
+    if invalid.Key == nil || *invalid.Key != "F#" {
+        t.Errorf("Normalize() key = %v, want valid fields to be kept", invalid.Key)
+    }
+}
+
+func TestTrackMetadataMergeAndClone(t *testing.T) {
+    stored, catalog := 90.0, 120.0
+    explicit := true
+    metadata := TrackMetadata{BPM: &stored}
+    metadata.Merge(TrackMetadata{BPM: &catalog, Explicit: &explicit})
+    if *metadata.BPM != 90 || metadata.Explicit == nil || !*metadata.Explicit {
+        t.Errorf("Merge() = %+v, want only missing fields to be filled", metadata)
+    }
+
+    clone := metadata.Clone()
+    *clone.BPM = 100
+    if *metadata.BPM != 90 {
+        t.Error("Clone() shares values with the original")
+    }
+}
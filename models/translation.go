@@ -0,0 +1,63 @@
+package models
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// TranslationSource — происхождение перевода
+type TranslationSource string
+
+const (
+    TranslationOfficial  TranslationSource = "official"  // Официальный перевод (от исполнителя или издателя)
+    TranslationCommunity TranslationSource = "community" // Перевод пользователя или сообщества
+    TranslationMachine   TranslationSource = "machine"   // Машинный перевод
+)
+
+// Translation — перевод текста песни на один язык
+type Translation struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+    SongID    uint      `gorm:"uniqueIndex:idx_song_translations_song_language" json:"song_id"`
+    // Код языка BCP 47 в канонической записи: ru, en, pt-BR
+    Language string `gorm:"uniqueIndex:idx_song_translations_song_language" json:"language" example:"ru"`
+    Text     string `json:"text"`
+    // Текст перевода, разобранный на части; части сопоставляются с частями оригинала по номеру
+    Sections   LyricSections     `gorm:"type:jsonb" json:"sections"`
+    Source     TranslationSource `json:"source" example:"community"`
+    Translator string            `json:"translator,omitempty" example:"Иван Петров"`
+    SourceURL  string            `json:"source_url,omitempty" example:"https://example.com/translations/42"`
+}
+
+// TableName задаёт имя таблицы переводов
+func (Translation) TableName() string {
+    return "song_translations"
+}
+
+// BeforeSave разбирает текст перевода на части перед каждой записью в базу данных
+func (t *Translation) BeforeSave(tx *gorm.DB) error {
+    t.Sections = ParseSections(t.Text)
+    return nil
+}
+
+// TranslationRequest используется при добавлении и замене перевода
+type TranslationRequest struct {
+    Text       string            `json:"text" binding:"required"`
+    Source     TranslationSource `json:"source" binding:"required,oneof=official community machine" example:"community"`
+    Translator string            `json:"translator" example:"Иван Петров"`
+    SourceURL  string            `json:"source_url" example:"https://example.com/translations/42"`
+}
+
+// AlignedVerse — часть (или строка) перевода рядом с частью оригинала с тем же номером.
+// Для format=text и format=lines стороны — строки, для format=sections — части целиком;
+// сторона равна null, если у части нет соответствия.
+type AlignedVerse struct {
+    Index       int         `json:"index" example:"2"` // Номер части в песне
+    Line        int         `json:"line,omitempty"`    // Номер строки в части (format=lines)
+    Type        SectionType `json:"type" example:"chorus"`
+    Label       string      `json:"label" example:"Chorus"`
+    Original    interface{} `json:"original"`
+    Translation interface{} `json:"translation"`
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+    "errors"
+    "fmt"
+    "go-tunes/models"
+    "log"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// CreateAlbum saves a new album with its track listing
+func (repo *SongRepository) CreateAlbum(album *models.Album, songIDs []uint) (*models.Album, error) {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if album.ArtistID != nil {
+            if err := tx.First(&models.Artist{}, *album.ArtistID).Error; err != nil {
+                if errors.Is(err, gorm.ErrRecordNotFound) {
+                    return ErrArtistNotFound
+                }
+                return err
+            }
+        }
+        if err := tx.Create(album).Error; err != nil {
+            return err
+        }
+        return insertTracks(tx, album.ID, songIDs)
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to save album %s, error: %v\n", album.Title, err)
+        return nil, err
+    }
+    log.Printf("INFO: Successfully saved album with ID: %d\n", album.ID)
+    return repo.GetAlbumByID(album.ID)
+}
+
+// GetAlbums retrieves albums matching the filters, newest releases first, along with their total count
+func (repo *SongRepository) GetAlbums(albumQuery AlbumQuery) ([]models.Album, int64, error) {
+    log.Printf("INFO: Retrieving albums. Page: %d, Limit: %d\n", albumQuery.Page, albumQuery.Limit)
+    query := repo.DB.Model(&models.Album{})
+    if albumQuery.Title != "" {
+        query = query.Where("title ILIKE ?", "%"+albumQuery.Title+"%")
+    }
+    if albumQuery.ArtistID != 0 {
+        query = query.Where("artist_id = ?", albumQuery.ArtistID)
+    }
+    if albumQuery.Type != "" {
+        query = query.Where("type = ?", albumQuery.Type)
+    }
+    query = query.Session(&gorm.Session{})
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        log.Printf("ERROR: Failed to count albums, error: %v\n", err)
+        return nil, 0, err
+    }
+    var albums []models.Album
+    offset := (albumQuery.Page - 1) * albumQuery.Limit
+    err := query.Order("release_date DESC NULLS LAST, id DESC").Limit(albumQuery.Limit).Offset(offset).Find(&albums).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to retrieve albums, error: %v\n", err)
+        return nil, 0, err
+    }
+    return albums, total, nil
+}
+
+// GetAlbumByID retrieves an album with its track listing
+func (repo *SongRepository) GetAlbumByID(id uint) (*models.Album, error) {
+    var album models.Album
+    if err := repo.DB.First(&album, id).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            log.Printf("ERROR: Album with ID: %d not found\n", id)
+            return nil, ErrAlbumNotFound
+        }
+        log.Printf("ERROR: Failed to retrieve album with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    album.Tracks = []models.Track{}
+    err := repo.DB.Table("album_tracks").
+        Select("album_tracks.position, album_tracks.song_id, songs.\"group\", songs.song").
+        Joins("JOIN songs ON songs.id = album_tracks.song_id AND songs.deleted_at IS NULL").
+        Where("album_tracks.album_id = ?", id).
+        Order("album_tracks.position").
+        Scan(&album.Tracks).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to retrieve tracks of album with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    return &album, nil
+}
+
+// SetAlbumTracks replaces the track listing of an album
+func (repo *SongRepository) SetAlbumTracks(id uint, songIDs []uint) (*models.Album, error) {
+    log.Printf("INFO: Setting %d tracks of album with ID: %d\n", len(songIDs), id)
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        var album models.Album
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&album, id).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return ErrAlbumNotFound
+            }
+            return err
+        }
+        if err := tx.Where("album_id = ?", id).Delete(&models.AlbumTrack{}).Error; err != nil {
+            return err
+        }
+        if err := insertTracks(tx, id, songIDs); err != nil {
+            return err
+        }
+        return tx.Model(&album).UpdateColumn("updated_at", gorm.Expr("now()")).Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to set tracks of album with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    return repo.GetAlbumByID(id)
+}
+
+// DeleteAlbum deletes an album with its track listing; the songs themselves are kept
+func (repo *SongRepository) DeleteAlbum(id uint) error {
+    log.Printf("INFO: Deleting album with ID: %d\n", id)
+    result := repo.DB.Delete(&models.Album{}, id)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to delete album with ID: %d, error: %v\n", id, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Album with ID: %d not found for deletion\n", id)
+        return ErrAlbumNotFound
+    }
+    log.Printf("INFO: Successfully deleted album with ID: %d\n", id)
+    return nil
+}
+
+// songAlbumRow — строка выборки альбомов песен
+type songAlbumRow struct {
+    SongID uint
+    models.SongAlbum
+}
+
+// GetSongAlbums retrieves the albums of the given songs, earliest releases first
+func (repo *SongRepository) GetSongAlbums(songIDs []uint) (map[uint][]models.SongAlbum, error) {
+    albums := make(map[uint][]models.SongAlbum)
+    if len(songIDs) == 0 {
+        return albums, nil
+    }
+    var rows []songAlbumRow
+    err := repo.DB.Table("album_tracks").
+        Select("album_tracks.song_id, album_tracks.album_id, albums.title, albums.type, "+
+            "albums.release_date, albums.release_date_precision, album_tracks.position").
+        Joins("JOIN albums ON albums.id = album_tracks.album_id").
+        Where("album_tracks.song_id IN ?", songIDs).
+        Order("albums.release_date NULLS LAST, albums.id").
+        Scan(&rows).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to retrieve albums of songs, error: %v\n", err)
+        return nil, err
+    }
+    for _, row := range rows {
+        albums[row.SongID] = append(albums[row.SongID], row.SongAlbum)
+    }
+    return albums, nil
+}
+
+// insertTracks сохраняет композиции альбома в порядке songIDs, проверяя, что все песни существуют и не удалены
+func insertTracks(tx *gorm.DB, albumID uint, songIDs []uint) error {
+    if len(songIDs) == 0 {
+        return nil
+    }
+    var existing []uint
+    if err := tx.Model(&models.Song{}).Where("id IN ?", songIDs).Pluck("id", &existing).Error; err != nil {
+        return err
+    }
+    found := make(map[uint]bool, len(existing))
+    for _, id := range existing {
+        found[id] = true
+    }
+    tracks := make([]models.AlbumTrack, 0, len(songIDs))
+    for i, songID := range songIDs {
+        if !found[songID] {
+            return fmt.Errorf("song ID %d: %w", songID, ErrSongNotFound)
+        }
+        tracks = append(tracks, models.AlbumTrack{AlbumID: albumID, SongID: songID, Position: i + 1})
+    }
+    return tx.Create(&tracks).Error
+}
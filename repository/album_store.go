@@ -0,0 +1,40 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+)
+
+var (
+    // ErrAlbumNotFound возвращается хранилищем, когда альбом не найден
+    ErrAlbumNotFound = errors.New("album not found")
+    // ErrArtistHasAlbums возвращается при попытке удалить исполнителя, у которого есть альбомы
+    ErrArtistHasAlbums = errors.New("artist has albums")
+)
+
+// AlbumQuery описывает запрос списка альбомов
+type AlbumQuery struct {
+    Title    string           // Подстрока названия без учёта регистра
+    ArtistID uint             // Альбомы исполнителя; 0 — без отбора
+    Type     models.AlbumType // Тип релиза; пустая строка — без отбора
+    Page     int
+    Limit    int
+}
+
+// AlbumStore описывает хранилище альбомов и списков их композиций.
+// Композиции ссылаются на неудалённые песни; песни из корзины не выводятся в списке, окончательное
+// удаление песни убирает её со всех альбомов.
+type AlbumStore interface {
+    // CreateAlbum сохраняет альбом со списком композиций из песен songIDs в указанном порядке.
+    // Если исполнителя или одной из песен нет, возвращается ErrArtistNotFound или ErrSongNotFound.
+    CreateAlbum(album *models.Album, songIDs []uint) (*models.Album, error)
+    // GetAlbums возвращает страницу альбомов без композиций, от новых к старым, и их общее количество
+    GetAlbums(query AlbumQuery) ([]models.Album, int64, error)
+    // GetAlbumByID возвращает альбом вместе со списком композиций
+    GetAlbumByID(id uint) (*models.Album, error)
+    // SetAlbumTracks заменяет список композиций альбома песнями songIDs в указанном порядке
+    SetAlbumTracks(id uint, songIDs []uint) (*models.Album, error)
+    DeleteAlbum(id uint) error
+    // GetSongAlbums возвращает альбомы, на которых вышли песни, по ID песни, от ранних релизов к поздним
+    GetSongAlbums(songIDs []uint) (map[uint][]models.SongAlbum, error)
+}
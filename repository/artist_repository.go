@@ -0,0 +1,343 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+    "go-tunes/translit"
+    "log"
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// CreateArtist saves a new artist to the database
+func (repo *SongRepository) CreateArtist(artist *models.Artist) (*models.Artist, error) {
+    artist.UpdateDerivedFields()
+    if _, err := lookupArtist(repo.DB, artist.NameKey); err == nil {
+        log.Printf("ERROR: Artist %s already exists\n", artist.Name)
+        return nil, ErrArtistExists
+    } else if !errors.Is(err, ErrArtistNotFound) {
+        return nil, err
+    }
+    if err := repo.DB.Create(artist).Error; err != nil {
+        log.Printf("ERROR: Failed to save artist %s, error: %v\n", artist.Name, err)
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return nil, ErrArtistExists
+        }
+        return nil, err
+    }
+    log.Printf("INFO: Successfully saved artist with ID: %d\n", artist.ID)
+    return artist, nil
+}
+
+// GetArtists retrieves artists matching the name filter ordered by name, along with their total count
+func (repo *SongRepository) GetArtists(artistQuery ArtistQuery) ([]models.Artist, int64, error) {
+    log.Printf("INFO: Retrieving artists. Page: %d, Limit: %d\n", artistQuery.Page, artistQuery.Limit)
+    query := repo.DB.Model(&models.Artist{})
+    if artistQuery.Name != "" {
+        pattern := "%" + models.ArtistKey(artistQuery.Name) + "%"
+        query = query.Where("name_key LIKE ? OR EXISTS (SELECT 1 FROM jsonb_array_elements_text(alias_keys) AS alias_key WHERE alias_key LIKE ?)",
+            pattern, pattern)
+    }
+    query = query.Session(&gorm.Session{})
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        log.Printf("ERROR: Failed to count artists, error: %v\n", err)
+        return nil, 0, err
+    }
+    var artists []models.Artist
+    offset := (artistQuery.Page - 1) * artistQuery.Limit
+    if err := query.Order("name, id").Limit(artistQuery.Limit).Offset(offset).Find(&artists).Error; err != nil {
+        log.Printf("ERROR: Failed to retrieve artists, error: %v\n", err)
+        return nil, 0, err
+    }
+    return artists, total, nil
+}
+
+// GetArtistByID retrieves an artist by ID
+func (repo *SongRepository) GetArtistByID(id uint) (*models.Artist, error) {
+    var artist models.Artist
+    if err := repo.DB.First(&artist, id).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            log.Printf("ERROR: Artist with ID: %d not found\n", id)
+            return nil, ErrArtistNotFound
+        }
+        log.Printf("ERROR: Failed to retrieve artist with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    return &artist, nil
+}
+
+// UpdateArtist updates an artist and renames the group of all its songs, including songs in the trash
+func (repo *SongRepository) UpdateArtist(artist *models.Artist) (*models.Artist, error) {
+    log.Printf("INFO: Updating artist with ID: %d\n", artist.ID)
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        var existing models.Artist
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, artist.ID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return ErrArtistNotFound
+            }
+            return err
+        }
+        artist.UpdateDerivedFields()
+        if other, err := lookupArtist(tx, artist.NameKey); err == nil && other.ID != artist.ID {
+            return ErrArtistExists
+        } else if err != nil && !errors.Is(err, ErrArtistNotFound) {
+            return err
+        }
+        artist.CreatedAt = existing.CreatedAt
+        if err := tx.Save(artist).Error; err != nil {
+            if errors.Is(err, gorm.ErrDuplicatedKey) {
+                return ErrArtistExists
+            }
+            return err
+        }
+        if artist.Name == existing.Name {
+            return nil
+        }
+        err := tx.Unscoped().Model(&models.Song{}).Where("artist_id = ?", artist.ID).
+            UpdateColumns(map[string]interface{}{
+                "group":      artist.Name,
+                "group_key":  translit.Key(artist.Name),
+                "updated_at": gorm.Expr("now()"),
+            }).Error
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return ErrSongExists
+        }
+        return err
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to update artist with ID: %d, error: %v\n", artist.ID, err)
+        return nil, err
+    }
+    log.Printf("INFO: Successfully updated artist with ID: %d\n", artist.ID)
+    return artist, nil
+}
+
+// DeleteArtist deletes an artist that has no albums and no songs, including songs in the trash
+func (repo *SongRepository) DeleteArtist(id uint) error {
+    log.Printf("INFO: Deleting artist with ID: %d\n", id)
+    var songs int64
+    if err := repo.DB.Unscoped().Model(&models.Song{}).Where("artist_id = ?", id).Count(&songs).Error; err != nil {
+        log.Printf("ERROR: Failed to count songs of artist with ID: %d, error: %v\n", id, err)
+        return err
+    }
+    if songs > 0 {
+        return ErrArtistHasSongs
+    }
+    var albums int64
+    if err := repo.DB.Model(&models.Album{}).Where("artist_id = ?", id).Count(&albums).Error; err != nil {
+        log.Printf("ERROR: Failed to count albums of artist with ID: %d, error: %v\n", id, err)
+        return err
+    }
+    if albums > 0 {
+        return ErrArtistHasAlbums
+    }
+    result := repo.DB.Delete(&models.Artist{}, id)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to delete artist with ID: %d, error: %v\n", id, result.Error)
+        if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+            return ErrArtistHasSongs
+        }
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Artist with ID: %d not found for deletion\n", id)
+        return ErrArtistNotFound
+    }
+    log.Printf("INFO: Successfully deleted artist with ID: %d\n", id)
+    return nil
+}
+
+// FindArtist retrieves an artist by its name or one of its aliases, ignoring case and transliteration
+func (repo *SongRepository) FindArtist(name string) (*models.Artist, error) {
+    artist, err := lookupArtist(repo.DB, models.ArtistKey(name))
+    if err != nil && !errors.Is(err, ErrArtistNotFound) {
+        log.Printf("ERROR: Failed to look up artist %s, error: %v\n", name, err)
+    }
+    return artist, err
+}
+
+// lookupArtist ищет исполнителя по ключу названия, а если такого нет — по ключам псевдонимов
+func lookupArtist(db *gorm.DB, key string) (*models.Artist, error) {
+    var artist models.Artist
+    err := db.Where("name_key = ?", key).First(&artist).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        err = db.Where("alias_keys @> jsonb_build_array(?::text)", key).Order("id").First(&artist).Error
+    }
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil, ErrArtistNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &artist, nil
+}
+
+// assignArtist связывает песню с исполнителем по названию группы, создавая исполнителя при необходимости,
+// и приводит название группы к названию исполнителя
+func assignArtist(tx *gorm.DB, song *models.Song) error {
+    song.Group = strings.TrimSpace(song.Group)
+    artist, err := findOrCreateArtist(tx, song.Group)
+    if err != nil {
+        return err
+    }
+    song.Group = artist.Name
+    song.ArtistID = &artist.ID
+    return nil
+}
+
+// findOrCreateArtist ищет исполнителя по названию или псевдониму и создаёт его, если не найден
+func findOrCreateArtist(tx *gorm.DB, name string) (*models.Artist, error) {
+    key := models.ArtistKey(name)
+    artist, err := lookupArtist(tx, key)
+    if errors.Is(err, ErrArtistNotFound) {
+        // Исполнителя мог одновременно создать другой запрос: тогда вставка пропускается и он читается заново
+        artist = &models.Artist{Name: strings.TrimSpace(name)}
+        err = tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name_key"}}, DoNothing: true}).Create(artist).Error
+        if err == nil && artist.ID == 0 {
+            artist, err = lookupArtist(tx, key)
+        }
+    }
+    if err != nil {
+        return nil, err
+    }
+    return artist, nil
+}
+
+// BackfillArtists completes the migration of group names into artists: fills name keys of migrated artists,
+// merges artists whose names differ only by transliteration (e.g. Кино and Kino) and links songs saved without an artist.
+// With renameGroups the group of linked songs is renamed to the canonical artist name where this does not collide
+// with another song of the artist. Songs are never deleted.
+func (repo *SongRepository) BackfillArtists(renameGroups bool) error {
+    var pending []models.Artist
+    if err := repo.DB.Where("name_key IS NULL").Order("id").Find(&pending).Error; err != nil {
+        return err
+    }
+    for i := range pending {
+        artist := &pending[i]
+        err := repo.DB.Transaction(func(tx *gorm.DB) error {
+            existing, err := lookupArtist(tx, models.ArtistKey(artist.Name))
+            if errors.Is(err, ErrArtistNotFound) {
+                return tx.Save(artist).Error
+            }
+            if err != nil {
+                return err
+            }
+            // Название становится псевдонимом ранее найденного исполнителя, песни переходят к нему
+            if err := tx.Unscoped().Model(&models.Song{}).Where("artist_id = ?", artist.ID).
+                UpdateColumn("artist_id", existing.ID).Error; err != nil {
+                return err
+            }
+            existing.Aliases = append(existing.Aliases, artist.Name)
+            if err := tx.Save(existing).Error; err != nil {
+                return err
+            }
+            log.Printf("INFO: Merged artist %q into %q (ID: %d).\n", artist.Name, existing.Name, existing.ID)
+            return tx.Delete(artist).Error
+        })
+        if err != nil {
+            return err
+        }
+    }
+
+    var songs []models.Song
+    linked := 0
+    result := repo.DB.Unscoped().Where("artist_id IS NULL").
+        FindInBatches(&songs, 500, func(tx *gorm.DB, batch int) error {
+            for i := range songs {
+                if err := assignArtist(repo.DB, &songs[i]); err != nil {
+                    return err
+                }
+                err := repo.DB.Unscoped().Model(&models.Song{}).Where("id = ?", songs[i].ID).
+                    UpdateColumn("artist_id", songs[i].ArtistID).Error
+                if err != nil {
+                    return err
+                }
+            }
+            linked += len(songs)
+            return nil
+        })
+    if result.Error != nil {
+        return result.Error
+    }
+
+    // Песни, связанные с исполнителем по другому написанию или псевдониму, сохраняют своё название группы,
+    // пока переименование не включено явно: поиск по группе находит их и так, по ключу названия и псевдонимам
+    var mismatched []models.Artist
+    err := repo.DB.Where("id IN (SELECT songs.artist_id FROM songs JOIN artists ON artists.id = songs.artist_id WHERE songs.\"group\" <> artists.name)").
+        Order("id").Find(&mismatched).Error
+    if err != nil {
+        return err
+    }
+    renamed, skipped := 0, 0
+    for i := range mismatched {
+        if !renameGroups {
+            var ids []uint
+            if err := mismatchedSongs(repo.DB, &mismatched[i]).Order("id").Pluck("id", &ids).Error; err != nil {
+                return err
+            }
+            log.Printf("INFO: Songs %v of artist %q (ID: %d) keep group names that differ from the artist name.\n",
+                ids, mismatched[i].Name, mismatched[i].ID)
+            skipped += len(ids)
+            continue
+        }
+        err := repo.DB.Transaction(func(tx *gorm.DB) error {
+            songs, collisions, err := canonicalizeGroups(tx, &mismatched[i])
+            renamed, skipped = renamed+songs, skipped+collisions
+            return err
+        })
+        if err != nil {
+            return err
+        }
+    }
+
+    if len(pending) > 0 || linked > 0 || renamed > 0 || skipped > 0 {
+        log.Printf("INFO: Backfilled artists: %d migrated names, %d songs linked, %d groups renamed, %d groups left as is.\n",
+            len(pending), linked, renamed, skipped)
+    }
+    return nil
+}
+
+// mismatchedSongs отбирает песни исполнителя, название группы которых отличается от названия исполнителя
+func mismatchedSongs(tx *gorm.DB, artist *models.Artist) *gorm.DB {
+    return tx.Unscoped().Model(&models.Song{}).Where("artist_id = ? AND \"group\" <> ?", artist.ID, artist.Name)
+}
+
+// groupCollision находит песни, которые после переименования группы совпали бы с другой неудалённой песней
+// исполнителя: уже названной каноническим названием или переименовываемой вместе с ними, но с меньшим ID
+const groupCollision = `EXISTS (SELECT 1 FROM songs AS other WHERE other.deleted_at IS NULL AND other.id <> songs.id
+    AND other.artist_id = songs.artist_id AND other.song = songs.song AND (other."group" = ? OR other.id < songs.id))`
+
+// canonicalizeGroups приводит название группы песен исполнителя к его каноническому названию и возвращает количество
+// переименованных и пропущенных песен. Песни ни при каких условиях не удаляются: песня, которая после переименования
+// совпала бы с другой песней исполнителя (например, "muse" и "Muse" с одним названием), остаётся как есть.
+// ID переименованных и пропущенных песен записываются в журнал.
+func canonicalizeGroups(tx *gorm.DB, artist *models.Artist) (int, int, error) {
+    var collisions, ids []uint
+    if err := mismatchedSongs(tx, artist).Where(groupCollision, artist.Name).Order("id").Pluck("id", &collisions).Error; err != nil {
+        return 0, 0, err
+    }
+    if err := mismatchedSongs(tx, artist).Where("NOT "+groupCollision, artist.Name).Order("id").Pluck("id", &ids).Error; err != nil {
+        return 0, 0, err
+    }
+    if len(ids) > 0 {
+        err := tx.Unscoped().Model(&models.Song{}).Where("id IN ?", ids).
+            UpdateColumns(map[string]interface{}{
+                "group":      artist.Name,
+                "group_key":  translit.Key(artist.Name),
+                "updated_at": gorm.Expr("now()"),
+            }).Error
+        if err != nil {
+            return 0, 0, err
+        }
+        log.Printf("INFO: Renamed group of songs %v to artist name %q (ID: %d).\n", ids, artist.Name, artist.ID)
+    }
+    if len(collisions) > 0 {
+        log.Printf("WARNING: Songs %v of artist %q (ID: %d) were not renamed: another song of the artist has the same title.\n",
+            collisions, artist.Name, artist.ID)
+    }
+    return len(ids), len(collisions), nil
+}
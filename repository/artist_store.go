@@ -0,0 +1,37 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+)
+
+var (
+    // ErrArtistNotFound возвращается хранилищем, когда исполнитель не найден
+    ErrArtistNotFound = errors.New("artist not found")
+    // ErrArtistExists возвращается, если название исполнителя совпадает с названием или псевдонимом другого исполнителя
+    ErrArtistExists = errors.New("artist already exists")
+    // ErrArtistHasSongs возвращается при попытке удалить исполнителя, у которого есть песни (в том числе в корзине)
+    ErrArtistHasSongs = errors.New("artist has songs")
+)
+
+// ArtistQuery описывает запрос списка исполнителей
+type ArtistQuery struct {
+    Name  string // Подстрока названия или псевдонима без учёта регистра и транслитерации
+    Page  int
+    Limit int
+}
+
+// ArtistStore описывает хранилище исполнителей. Песни связываются с исполнителями при сохранении:
+// исполнитель ищется по названию группы среди названий и псевдонимов и создаётся, если не найден.
+type ArtistStore interface {
+    CreateArtist(artist *models.Artist) (*models.Artist, error)
+    // GetArtists возвращает страницу исполнителей, упорядоченных по названию, и их общее количество
+    GetArtists(query ArtistQuery) ([]models.Artist, int64, error)
+    GetArtistByID(id uint) (*models.Artist, error)
+    // FindArtist ищет исполнителя по названию или псевдониму без учёта регистра, пунктуации и транслитерации
+    FindArtist(name string) (*models.Artist, error)
+    // UpdateArtist изменяет исполнителя; при переименовании название группы меняется и у всех его песен
+    UpdateArtist(artist *models.Artist) (*models.Artist, error)
+    // DeleteArtist удаляет исполнителя без песен и альбомов
+    DeleteArtist(id uint) error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+    "go-tunes/models"
+    "log"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// GetSongCredits retrieves the credits of a song ordered by role and artist name
+func (repo *SongRepository) GetSongCredits(songID uint) ([]models.SongCredit, error) {
+    credits := []models.SongCredit{}
+    err := repo.DB.Select("song_credits.*, artists.name").
+        Joins("JOIN artists ON artists.id = song_credits.artist_id").
+        Where("song_credits.song_id = ?", songID).
+        Order("song_credits.role, artists.name, artists.id").
+        Find(&credits).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to retrieve credits of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return credits, nil
+}
+
+// AddSongCredit credits an artist, found by name or alias or created, with a role on a song
+func (repo *SongRepository) AddSongCredit(songID uint, name string, role models.CreditRole) (*models.SongCredit, bool, error) {
+    credit := &models.SongCredit{SongID: songID, Role: role}
+    created := false
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Select("id").First(&models.Song{}, songID).Error; err != nil {
+            return notFound(err)
+        }
+        artist, err := findOrCreateArtist(tx, name)
+        if err != nil {
+            return err
+        }
+        credit.ArtistID, credit.Name = artist.ID, artist.Name
+        result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(credit)
+        created = result.RowsAffected > 0
+        return result.Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to credit %s as %s on song ID: %d, error: %v\n", name, role, songID, err)
+        return nil, false, err
+    }
+    if created {
+        log.Printf("INFO: Credited artist ID: %d as %s on song ID: %d\n", credit.ArtistID, role, songID)
+    }
+    return credit, created, nil
+}
+
+// RemoveSongCredit removes the credit of an artist with a role from a song
+func (repo *SongRepository) RemoveSongCredit(songID, artistID uint, role models.CreditRole) error {
+    result := repo.DB.Where("song_id = ? AND artist_id = ? AND role = ?", songID, artistID, role).Delete(&models.SongCredit{})
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to remove credit of artist ID: %d from song ID: %d, error: %v\n", artistID, songID, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return ErrCreditNotFound
+    }
+    log.Printf("INFO: Removed %s credit of artist ID: %d from song ID: %d\n", role, artistID, songID)
+    return nil
+}
+
+// creditCondition строит подзапрос песен с участником, название или псевдоним которого содержит name, в роли role
+func creditCondition(name string, role models.CreditRole) (string, []interface{}) {
+    condition := "songs.id IN (SELECT song_credits.song_id FROM song_credits JOIN artists ON artists.id = song_credits.artist_id WHERE TRUE"
+    var args []interface{}
+    if name != "" {
+        pattern := "%" + models.ArtistKey(name) + "%"
+        condition += " AND (artists.name_key LIKE ? OR EXISTS (SELECT 1 FROM jsonb_array_elements_text(artists.alias_keys) AS alias_key WHERE alias_key LIKE ?))"
+        args = append(args, pattern, pattern)
+    }
+    if role != "" {
+        condition += " AND song_credits.role = ?"
+        args = append(args, role)
+    }
+    return condition + ")", args
+}
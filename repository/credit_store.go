@@ -0,0 +1,20 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+)
+
+// ErrCreditNotFound возвращается хранилищем, когда исполнитель не участвует в песне в указанной роли
+var ErrCreditNotFound = errors.New("credit not found")
+
+// CreditStore описывает хранилище участников песен: авторов, продюсеров и приглашённых исполнителей.
+// Участники — исполнители из ArtistStore; при удалении исполнителя его участие в песнях удаляется.
+type CreditStore interface {
+    // GetSongCredits возвращает участников песни, упорядоченных по роли и названию
+    GetSongCredits(songID uint) ([]models.SongCredit, error)
+    // AddSongCredit добавляет участника песни по названию или псевдониму исполнителя, создавая исполнителя
+    // при необходимости. Второе значение сообщает, было ли участие добавлено (а не существовало раньше).
+    AddSongCredit(songID uint, name string, role models.CreditRole) (*models.SongCredit, bool, error)
+    RemoveSongCredit(songID, artistID uint, role models.CreditRole) error
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+    "fmt"
+    "go-tunes/models"
+    "log"
+    "sort"
+    "time"
+)
+
+// CreateAlbum saves a new album with its track listing in memory
+func (repo *MemorySongRepository) CreateAlbum(album *models.Album, songIDs []uint) (*models.Album, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if album.ArtistID != nil {
+        if _, ok := repo.artists[*album.ArtistID]; !ok {
+            return nil, ErrArtistNotFound
+        }
+    }
+    tracks, err := repo.newTracksLocked(songIDs)
+    if err != nil {
+        log.Printf("ERROR: Failed to save album %s, error: %v\n", album.Title, err)
+        return nil, err
+    }
+    now := time.Now()
+    album.ID = repo.nextAlbumID
+    album.CreatedAt = now
+    album.UpdatedAt = now
+    repo.nextAlbumID++
+    album.Tracks = nil
+    repo.albums[album.ID] = *album
+    repo.albumTracks[album.ID] = tracks
+    log.Printf("INFO: Successfully saved album with ID: %d\n", album.ID)
+    return repo.albumLocked(album.ID), nil
+}
+
+// GetAlbums retrieves albums matching the filters, newest releases first, along with their total count
+func (repo *MemorySongRepository) GetAlbums(query AlbumQuery) ([]models.Album, int64, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    matched := make([]models.Album, 0, len(repo.albums))
+    for _, album := range repo.albums {
+        if containsFold(album.Title, query.Title) &&
+            (query.ArtistID == 0 || (album.ArtistID != nil && *album.ArtistID == query.ArtistID)) &&
+            (query.Type == "" || album.Type == query.Type) {
+            matched = append(matched, album)
+        }
+    }
+    // Порядок совпадает с PostgreSQL-хранилищем: release_date DESC NULLS LAST, id DESC
+    sort.Slice(matched, func(i, j int) bool {
+        a, b := matched[i].ReleaseDate.Date, matched[j].ReleaseDate.Date
+        if (a == nil) != (b == nil) {
+            return b == nil
+        }
+        if a != nil && !a.Equal(*b) {
+            return a.After(*b)
+        }
+        return matched[i].ID > matched[j].ID
+    })
+
+    offset := (query.Page - 1) * query.Limit
+    if offset >= len(matched) {
+        return []models.Album{}, int64(len(matched)), nil
+    }
+    end := min(offset+query.Limit, len(matched))
+    return matched[offset:end], int64(len(matched)), nil
+}
+
+// GetAlbumByID retrieves an album with its track listing
+func (repo *MemorySongRepository) GetAlbumByID(id uint) (*models.Album, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    if _, ok := repo.albums[id]; !ok {
+        log.Printf("ERROR: Album with ID: %d not found\n", id)
+        return nil, ErrAlbumNotFound
+    }
+    return repo.albumLocked(id), nil
+}
+
+// SetAlbumTracks replaces the track listing of an album
+func (repo *MemorySongRepository) SetAlbumTracks(id uint, songIDs []uint) (*models.Album, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    album, ok := repo.albums[id]
+    if !ok {
+        log.Printf("ERROR: Failed to set tracks of album with ID: %d\n", id)
+        return nil, ErrAlbumNotFound
+    }
+    tracks, err := repo.newTracksLocked(songIDs)
+    if err != nil {
+        log.Printf("ERROR: Failed to set tracks of album with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    album.UpdatedAt = time.Now()
+    repo.albums[id] = album
+    repo.albumTracks[id] = tracks
+    return repo.albumLocked(id), nil
+}
+
+// DeleteAlbum deletes an album with its track listing; the songs themselves are kept
+func (repo *MemorySongRepository) DeleteAlbum(id uint) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if _, ok := repo.albums[id]; !ok {
+        log.Printf("ERROR: Album with ID: %d not found for deletion\n", id)
+        return ErrAlbumNotFound
+    }
+    delete(repo.albums, id)
+    delete(repo.albumTracks, id)
+    log.Printf("INFO: Successfully deleted album with ID: %d\n", id)
+    return nil
+}
+
+// GetSongAlbums retrieves the albums of the given songs, earliest releases first
+func (repo *MemorySongRepository) GetSongAlbums(songIDs []uint) (map[uint][]models.SongAlbum, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    wanted := make(map[uint]bool, len(songIDs))
+    for _, id := range songIDs {
+        wanted[id] = true
+    }
+    albums := make(map[uint][]models.SongAlbum)
+    for albumID, tracks := range repo.albumTracks {
+        album := repo.albums[albumID]
+        for _, track := range tracks {
+            if wanted[track.SongID] {
+                albums[track.SongID] = append(albums[track.SongID], models.SongAlbum{
+                    AlbumID:     album.ID,
+                    Title:       album.Title,
+                    Type:        album.Type,
+                    ReleaseDate: album.ReleaseDate,
+                    Position:    track.Position,
+                })
+            }
+        }
+    }
+    // Порядок совпадает с PostgreSQL-хранилищем: release_date NULLS LAST, id
+    for _, list := range albums {
+        sort.Slice(list, func(i, j int) bool {
+            a, b := list[i].ReleaseDate.Date, list[j].ReleaseDate.Date
+            if (a == nil) != (b == nil) {
+                return b == nil
+            }
+            if a != nil && !a.Equal(*b) {
+                return a.Before(*b)
+            }
+            return list[i].AlbumID < list[j].AlbumID
+        })
+    }
+    return albums, nil
+}
+
+// newTracksLocked составляет композиции в порядке songIDs, проверяя, что все песни существуют и не удалены
+func (repo *MemorySongRepository) newTracksLocked(songIDs []uint) ([]models.AlbumTrack, error) {
+    tracks := make([]models.AlbumTrack, 0, len(songIDs))
+    for i, songID := range songIDs {
+        if song, ok := repo.songs[songID]; !ok || song.DeletedAt.Valid {
+            return nil, fmt.Errorf("song ID %d: %w", songID, ErrSongNotFound)
+        }
+        tracks = append(tracks, models.AlbumTrack{SongID: songID, Position: i + 1})
+    }
+    return tracks, nil
+}
+
+// albumLocked возвращает копию альбома со списком композиций; песни из корзины в список не входят
+func (repo *MemorySongRepository) albumLocked(id uint) *models.Album {
+    album := repo.albums[id]
+    album.Tracks = []models.Track{}
+    for _, track := range repo.albumTracks[id] {
+        song := repo.songs[track.SongID]
+        if song.DeletedAt.Valid {
+            continue
+        }
+        album.Tracks = append(album.Tracks, models.Track{
+            Position: track.Position,
+            SongID:   song.ID,
+            Group:    song.Group,
+            Song:     song.Song,
+        })
+    }
+    return &album
+}
+
+// removeSongTracksLocked убирает окончательно удалённую песню со всех альбомов
+func (repo *MemorySongRepository) removeSongTracksLocked(songID uint) {
+    for albumID, tracks := range repo.albumTracks {
+        kept := tracks[:0]
+        for _, track := range tracks {
+            if track.SongID != songID {
+                kept = append(kept, track)
+            }
+        }
+        repo.albumTracks[albumID] = kept
+    }
+}
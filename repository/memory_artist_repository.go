@@ -0,0 +1,220 @@
+package repository
+
+import (
+    "go-tunes/models"
+    "go-tunes/translit"
+    "log"
+    "sort"
+    "strings"
+    "time"
+)
+
+// CreateArtist saves a new artist in memory
+func (repo *MemorySongRepository) CreateArtist(artist *models.Artist) (*models.Artist, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    artist.UpdateDerivedFields()
+    if _, ok := repo.lookupArtistLocked(artist.NameKey); ok {
+        log.Printf("ERROR: Artist %s already exists\n", artist.Name)
+        return nil, ErrArtistExists
+    }
+    return repo.insertArtistLocked(artist), nil
+}
+
+func (repo *MemorySongRepository) insertArtistLocked(artist *models.Artist) *models.Artist {
+    now := time.Now()
+    artist.ID = repo.nextArtistID
+    artist.CreatedAt = now
+    artist.UpdatedAt = now
+    artist.UpdateDerivedFields()
+    repo.nextArtistID++
+    repo.artists[artist.ID] = *artist
+    log.Printf("INFO: Successfully saved artist with ID: %d\n", artist.ID)
+    return artist
+}
+
+// GetArtists retrieves artists matching the name filter ordered by name, along with their total count
+func (repo *MemorySongRepository) GetArtists(query ArtistQuery) ([]models.Artist, int64, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    key := models.ArtistKey(query.Name)
+    matched := make([]models.Artist, 0, len(repo.artists))
+    for _, artist := range repo.artists {
+        if query.Name == "" || matchesArtist(artist, key) {
+            matched = append(matched, artist)
+        }
+    }
+    sort.Slice(matched, func(i, j int) bool {
+        if matched[i].Name != matched[j].Name {
+            return matched[i].Name < matched[j].Name
+        }
+        return matched[i].ID < matched[j].ID
+    })
+
+    offset := (query.Page - 1) * query.Limit
+    if offset >= len(matched) {
+        return []models.Artist{}, int64(len(matched)), nil
+    }
+    end := min(offset+query.Limit, len(matched))
+    return matched[offset:end], int64(len(matched)), nil
+}
+
+// matchesArtist проверяет, содержит ли ключ названия или одного из псевдонимов исполнителя подстроку key
+func matchesArtist(artist models.Artist, key string) bool {
+    if strings.Contains(artist.NameKey, key) {
+        return true
+    }
+    for _, aliasKey := range artist.AliasKeys {
+        if strings.Contains(aliasKey, key) {
+            return true
+        }
+    }
+    return false
+}
+
+// aliasedArtistsLocked возвращает исполнителей, один из псевдонимов которых содержит строку фильтра по группе
+func (repo *MemorySongRepository) aliasedArtistsLocked(group string) map[uint]bool {
+    key := translit.Key(group)
+    if key == "" {
+        return nil
+    }
+    aliased := make(map[uint]bool)
+    for _, artist := range repo.artists {
+        for _, aliasKey := range artist.AliasKeys {
+            if strings.Contains(aliasKey, key) {
+                aliased[artist.ID] = true
+            }
+        }
+    }
+    return aliased
+}
+
+// GetArtistByID retrieves an artist by ID
+func (repo *MemorySongRepository) GetArtistByID(id uint) (*models.Artist, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    artist, ok := repo.artists[id]
+    if !ok {
+        log.Printf("ERROR: Artist with ID: %d not found\n", id)
+        return nil, ErrArtistNotFound
+    }
+    return &artist, nil
+}
+
+// UpdateArtist updates an artist and renames the group of its songs
+func (repo *MemorySongRepository) UpdateArtist(artist *models.Artist) (*models.Artist, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    existing, ok := repo.artists[artist.ID]
+    if !ok {
+        log.Printf("ERROR: Failed to update artist with ID: %d\n", artist.ID)
+        return nil, ErrArtistNotFound
+    }
+    artist.UpdateDerivedFields()
+    if other, ok := repo.lookupArtistLocked(artist.NameKey); ok && other.ID != artist.ID {
+        return nil, ErrArtistExists
+    }
+
+    // Песни исполнителя переименовываются все сразу, поэтому конфликты проверяются заранее
+    if artist.Name != existing.Name {
+        for _, song := range repo.songs {
+            if song.ArtistID == nil || *song.ArtistID != artist.ID || song.DeletedAt.Valid {
+                continue
+            }
+            if other, ok := repo.findLocked(artist.Name, song.Song); ok && other.ID != song.ID {
+                return nil, ErrSongExists
+            }
+        }
+        for id, song := range repo.songs {
+            if song.ArtistID != nil && *song.ArtistID == artist.ID {
+                song.Group = artist.Name
+                song.UpdatedAt = time.Now()
+                song.UpdateDerivedFields()
+                repo.songs[id] = song
+            }
+        }
+    }
+
+    artist.CreatedAt = existing.CreatedAt
+    artist.UpdatedAt = time.Now()
+    repo.artists[artist.ID] = *artist
+    log.Printf("INFO: Successfully updated artist with ID: %d\n", artist.ID)
+    return artist, nil
+}
+
+// DeleteArtist deletes an artist that has no songs or albums
+func (repo *MemorySongRepository) DeleteArtist(id uint) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if _, ok := repo.artists[id]; !ok {
+        log.Printf("ERROR: Artist with ID: %d not found for deletion\n", id)
+        return ErrArtistNotFound
+    }
+    for _, song := range repo.songs {
+        if song.ArtistID != nil && *song.ArtistID == id {
+            return ErrArtistHasSongs
+        }
+    }
+    for _, album := range repo.albums {
+        if album.ArtistID != nil && *album.ArtistID == id {
+            return ErrArtistHasAlbums
+        }
+    }
+    repo.removeArtistCreditsLocked(id)
+    delete(repo.artists, id)
+    log.Printf("INFO: Successfully deleted artist with ID: %d\n", id)
+    return nil
+}
+
+// FindArtist retrieves an artist by its name or one of its aliases, ignoring case and transliteration
+func (repo *MemorySongRepository) FindArtist(name string) (*models.Artist, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    artist, ok := repo.lookupArtistLocked(models.ArtistKey(name))
+    if !ok {
+        return nil, ErrArtistNotFound
+    }
+    return &artist, nil
+}
+
+// lookupArtistLocked ищет исполнителя по ключу названия, а если такого нет — по ключам псевдонимов
+func (repo *MemorySongRepository) lookupArtistLocked(key string) (models.Artist, bool) {
+    var found models.Artist
+    for _, artist := range repo.artists {
+        if artist.NameKey == key {
+            return artist, true
+        }
+    }
+    for _, artist := range repo.artists {
+        for _, aliasKey := range artist.AliasKeys {
+            if aliasKey == key && (found.ID == 0 || artist.ID < found.ID) {
+                found = artist
+            }
+        }
+    }
+    return found, found.ID != 0
+}
+
+// canonicalGroupLocked приводит название группы песни к названию найденного исполнителя
+func (repo *MemorySongRepository) canonicalGroupLocked(song *models.Song) {
+    song.Group = strings.TrimSpace(song.Group)
+    if artist, ok := repo.lookupArtistLocked(models.ArtistKey(song.Group)); ok {
+        song.Group = artist.Name
+    }
+}
+
+// assignArtistLocked связывает песню с исполнителем по названию группы, создавая исполнителя при необходимости
+func (repo *MemorySongRepository) assignArtistLocked(song *models.Song) {
+    artist, ok := repo.lookupArtistLocked(models.ArtistKey(song.Group))
+    if !ok {
+        artist = *repo.insertArtistLocked(&models.Artist{Name: song.Group})
+    }
+    song.Group = artist.Name
+    song.ArtistID = &artist.ID
+}
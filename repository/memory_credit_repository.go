@@ -0,0 +1,100 @@
+package repository
+
+import (
+    "go-tunes/models"
+    "log"
+    "sort"
+    "strings"
+    "time"
+)
+
+// GetSongCredits retrieves the credits of a song ordered by role and artist name
+func (repo *MemorySongRepository) GetSongCredits(songID uint) ([]models.SongCredit, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    credits := make([]models.SongCredit, 0, len(repo.credits[songID]))
+    for _, credit := range repo.credits[songID] {
+        credit.Name = repo.artists[credit.ArtistID].Name
+        credits = append(credits, credit)
+    }
+    sort.Slice(credits, func(i, j int) bool {
+        if credits[i].Role != credits[j].Role {
+            return credits[i].Role < credits[j].Role
+        }
+        if credits[i].Name != credits[j].Name {
+            return credits[i].Name < credits[j].Name
+        }
+        return credits[i].ArtistID < credits[j].ArtistID
+    })
+    return credits, nil
+}
+
+// AddSongCredit credits an artist, found by name or alias or created, with a role on a song
+func (repo *MemorySongRepository) AddSongCredit(songID uint, name string, role models.CreditRole) (*models.SongCredit, bool, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if song, ok := repo.songs[songID]; !ok || song.DeletedAt.Valid {
+        return nil, false, ErrSongNotFound
+    }
+    name = strings.TrimSpace(name)
+    artist, ok := repo.lookupArtistLocked(models.ArtistKey(name))
+    if !ok {
+        artist = *repo.insertArtistLocked(&models.Artist{Name: name})
+    }
+    for _, credit := range repo.credits[songID] {
+        if credit.ArtistID == artist.ID && credit.Role == role {
+            credit.Name = artist.Name
+            return &credit, false, nil
+        }
+    }
+    credit := models.SongCredit{SongID: songID, ArtistID: artist.ID, Role: role, CreatedAt: time.Now()}
+    repo.credits[songID] = append(repo.credits[songID], credit)
+    log.Printf("INFO: Credited artist ID: %d as %s on song ID: %d\n", artist.ID, role, songID)
+    credit.Name = artist.Name
+    return &credit, true, nil
+}
+
+// RemoveSongCredit removes the credit of an artist with a role from a song
+func (repo *MemorySongRepository) RemoveSongCredit(songID, artistID uint, role models.CreditRole) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    credits := repo.credits[songID]
+    for i, credit := range credits {
+        if credit.ArtistID == artistID && credit.Role == role {
+            repo.credits[songID] = append(credits[:i], credits[i+1:]...)
+            log.Printf("INFO: Removed %s credit of artist ID: %d from song ID: %d\n", role, artistID, songID)
+            return nil
+        }
+    }
+    return ErrCreditNotFound
+}
+
+// creditedSongsLocked возвращает песни с участником, название или псевдоним которого содержит name, в роли role
+func (repo *MemorySongRepository) creditedSongsLocked(name string, role models.CreditRole) map[uint]bool {
+    key := models.ArtistKey(name)
+    credited := make(map[uint]bool)
+    for songID, credits := range repo.credits {
+        for _, credit := range credits {
+            if (role == "" || credit.Role == role) && (name == "" || matchesArtist(repo.artists[credit.ArtistID], key)) {
+                credited[songID] = true
+            }
+        }
+    }
+    return credited
+}
+
+// removeArtistCreditsLocked удаляет участие удаляемого исполнителя во всех песнях
+func (repo *MemorySongRepository) removeArtistCreditsLocked(artistID uint) {
+    for songID, credits := range repo.credits {
+        kept := credits[:0]
+        for _, credit := range credits {
+            if credit.ArtistID != artistID {
+                kept = append(kept, credit)
+            }
+        }
+        repo.credits[songID] = kept
+    }
+}
@@ -0,0 +1,503 @@
+package repository
+
+import (
+    "go-tunes/models"
+    "go-tunes/translit"
+    "log"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// MemorySongRepository хранит песни в памяти процесса.
+// Используется для тестов и локальных демонстраций без PostgreSQL.
+type MemorySongRepository struct {
+    mu     sync.RWMutex
+    songs  map[uint]models.Song
+    nextID uint
+    // Переводы по ID песни и коду языка
+    translations      map[uint]map[string]models.Translation
+    nextTranslationID uint
+    artists           map[uint]models.Artist
+    nextArtistID      uint
+    albums            map[uint]models.Album
+    albumTracks       map[uint][]models.AlbumTrack // Композиции по ID альбома в порядке следования
+    nextAlbumID       uint
+    credits           map[uint][]models.SongCredit // Участники по ID песни
+    genres            map[uint]models.Genre
+    nextGenreID       uint
+    songGenres        map[uint][]uint   // ID жанров по ID песни
+    songTags          map[uint][]string // Теги по ID песни в алфавитном порядке
+}
+
+var _ Store = (*MemorySongRepository)(nil)
+
+func NewMemorySongRepository() *MemorySongRepository {
+    log.Println("INFO: Creating new MemorySongRepository.")
+    return &MemorySongRepository{
+        songs:             make(map[uint]models.Song),
+        nextID:            1,
+        translations:      make(map[uint]map[string]models.Translation),
+        nextTranslationID: 1,
+        artists:           make(map[uint]models.Artist),
+        nextArtistID:      1,
+        albums:            make(map[uint]models.Album),
+        albumTracks:       make(map[uint][]models.AlbumTrack),
+        nextAlbumID:       1,
+        credits:           make(map[uint][]models.SongCredit),
+        genres:            make(map[uint]models.Genre),
+        nextGenreID:       1,
+        songGenres:        make(map[uint][]uint),
+        songTags:          make(map[uint][]string),
+    }
+}
+
+// SaveSong saves a song in memory and assigns it a new ID
+func (repo *MemorySongRepository) SaveSong(song *models.Song) (*models.Song, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    repo.canonicalGroupLocked(song)
+    if _, ok := repo.findLocked(song.Group, song.Song); ok {
+        return nil, ErrSongExists
+    }
+    return repo.insertLocked(song), nil
+}
+
+// FirstOrCreateSong saves a song unless the group/song pair already exists
+func (repo *MemorySongRepository) FirstOrCreateSong(song *models.Song) (*models.Song, bool, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    repo.canonicalGroupLocked(song)
+    if existing, ok := repo.findLocked(song.Group, song.Song); ok {
+        return &existing, false, nil
+    }
+    return repo.insertLocked(song), true, nil
+}
+
+func (repo *MemorySongRepository) insertLocked(song *models.Song) *models.Song {
+    now := time.Now()
+    song.ID = repo.nextID
+    song.CreatedAt = now
+    song.UpdatedAt = now
+    song.DeletedAt = gorm.DeletedAt{}
+    repo.assignArtistLocked(song)
+    song.UpdateDerivedFields()
+    repo.nextID++
+    repo.songs[song.ID] = *song
+    log.Printf("INFO: Successfully saved song with ID: %d\n", song.ID)
+    return song
+}
+
+// FindSimilarSongs finds songs whose normalized group and title are similar to the given ones
+func (repo *MemorySongRepository) FindSimilarSongs(group, song string, limit int) ([]SongMatch, error) {
+    log.Printf("INFO: Looking for songs similar to group: %s, song: %s\n", group, song)
+    repo.mu.RLock()
+    candidates := make([]models.Song, 0, len(repo.songs))
+    for _, record := range repo.songs {
+        if !record.DeletedAt.Valid {
+            candidates = append(candidates, record)
+        }
+    }
+    repo.mu.RUnlock()
+    return rankMatches(candidates, group, song, limit), nil
+}
+
+// GetAllSongs retrieves songs matching the filter with sorting and pagination, along with their total count
+func (repo *MemorySongRepository) GetAllSongs(query SongQuery) (SongPage, error) {
+    log.Printf("INFO: Retrieving all songs. Page: %d, Limit: %d\n", query.Page, query.Limit)
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    matched := repo.filterLocked(query.Filter)
+    sort.Slice(matched, func(i, j int) bool { return lessSongs(matched[i], matched[j], query.Sort) })
+
+    result := SongPage{Total: int64(len(matched))}
+    if query.Cursor == nil {
+        result.Songs = paginate(matched, query.Page, query.Limit)
+        result.HasPrev = query.Page > 1
+        result.HasNext = (query.Page-1)*query.Limit+len(result.Songs) < len(matched)
+    } else {
+        result.Songs, result.HasNext, result.HasPrev = keysetPage(matched, query.Sort, query.Cursor, query.Limit)
+    }
+    log.Printf("INFO: Successfully retrieved %d of %d songs.\n", len(result.Songs), len(matched))
+    return result, nil
+}
+
+// SearchSongs performs a simplified full-text search over group, song title and lyrics
+func (repo *MemorySongRepository) SearchSongs(query SearchQuery) ([]models.SearchHit, int64, error) {
+    log.Printf("INFO: Searching songs. Query: %q, Page: %d, Limit: %d\n", query.Query, query.Page, query.Limit)
+    terms := searchTerms(query.Query)
+    if len(terms) == 0 {
+        return nil, 0, ErrEmptySearchQuery
+    }
+
+    repo.mu.RLock()
+    hits := make([]models.SearchHit, 0)
+    for _, song := range repo.songs {
+        if song.DeletedAt.Valid {
+            continue
+        }
+        if hit, ok := matchSong(song, terms); ok {
+            hits = append(hits, hit)
+        }
+    }
+    repo.mu.RUnlock()
+    sortSearchHits(hits)
+
+    total := len(hits)
+    offset := (query.Page - 1) * query.Limit
+    if offset > total {
+        offset = total
+    }
+    end := offset + query.Limit
+    if end > total {
+        end = total
+    }
+    log.Printf("INFO: Found %d songs, returning %d.\n", total, end-offset)
+    return hits[offset:end], int64(total), nil
+}
+
+// keysetPage выбирает из отсортированного списка страницу после (или перед) курсором
+func keysetPage(sorted []models.Song, sortFields []SortField, cursor *Cursor, limit int) ([]models.Song, bool, bool) {
+    // Индекс первой песни, идущей после позиции курсора
+    start := sort.Search(len(sorted), func(i int) bool { return lessSongs(cursor.Key, sorted[i], sortFields) })
+    if !cursor.Backward {
+        end := start + limit
+        if end > len(sorted) {
+            end = len(sorted)
+        }
+        // Перед страницей есть песни, если курсор стоит не перед первой песней
+        return append([]models.Song{}, sorted[start:end]...), end < len(sorted), start > 0
+    }
+
+    // Для курсора "назад" берём песни, идущие строго перед позицией курсора
+    end := sort.Search(len(sorted), func(i int) bool { return !lessSongs(sorted[i], cursor.Key, sortFields) })
+    begin := end - limit
+    if begin < 0 {
+        begin = 0
+    }
+    return append([]models.Song{}, sorted[begin:end]...), true, begin > 0
+}
+
+// GetSongByID retrieves a song by its ID
+func (repo *MemorySongRepository) GetSongByID(id uint) (*models.Song, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    song, ok := repo.songs[id]
+    if !ok || song.DeletedAt.Valid {
+        log.Printf("ERROR: Failed to retrieve song with ID: %d\n", id)
+        return nil, ErrSongNotFound
+    }
+    return &song, nil
+}
+
+// GetSongByGroupAndSong retrieves a song by its group and title; the group may be given by any name or alias of its artist
+func (repo *MemorySongRepository) GetSongByGroupAndSong(group, song string) (*models.Song, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    if artist, ok := repo.lookupArtistLocked(models.ArtistKey(group)); ok {
+        group = artist.Name
+    }
+    if record, ok := repo.findLocked(group, song); ok {
+        return &record, nil
+    }
+    return nil, ErrSongNotFound
+}
+
+// findLocked ищет неудалённую песню по паре группа+песня
+func (repo *MemorySongRepository) findLocked(group, song string) (models.Song, bool) {
+    for _, record := range repo.songs {
+        if !record.DeletedAt.Valid && record.Group == group && record.Song == song {
+            return record, true
+        }
+    }
+    return models.Song{}, false
+}
+
+// UpdateSong updates an existing song
+func (repo *MemorySongRepository) UpdateSong(song *models.Song) (*models.Song, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    existing, ok := repo.songs[song.ID]
+    if !ok || existing.DeletedAt.Valid {
+        log.Printf("ERROR: Failed to update song with ID: %d\n", song.ID)
+        return nil, ErrSongNotFound
+    }
+    repo.canonicalGroupLocked(song)
+    if other, ok := repo.findLocked(song.Group, song.Song); ok && other.ID != song.ID {
+        return nil, ErrSongExists
+    }
+    song.CreatedAt = existing.CreatedAt
+    song.UpdatedAt = time.Now()
+    repo.assignArtistLocked(song)
+    song.UpdateDerivedFields()
+    repo.songs[song.ID] = *song
+    log.Printf("INFO: Successfully updated song with ID: %d\n", song.ID)
+    return song, nil
+}
+
+// UpdateSyncedLyrics replaces only the synced lyrics of a song
+func (repo *MemorySongRepository) UpdateSyncedLyrics(id uint, lyrics string) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    song, ok := repo.songs[id]
+    if !ok || song.DeletedAt.Valid {
+        log.Printf("ERROR: Failed to update synced lyrics of song with ID: %d\n", id)
+        return ErrSongNotFound
+    }
+    song.SyncedLyrics = lyrics
+    song.UpdatedAt = time.Now()
+    repo.songs[id] = song
+    log.Printf("INFO: Successfully updated synced lyrics of song with ID: %d\n", id)
+    return nil
+}
+
+// DeleteSong moves a song to the trash
+func (repo *MemorySongRepository) DeleteSong(id uint) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    song, ok := repo.songs[id]
+    if !ok || song.DeletedAt.Valid {
+        log.Printf("ERROR: Failed to delete song with ID: %d\n", id)
+        return ErrSongNotFound
+    }
+    song.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+    repo.songs[id] = song
+    log.Printf("INFO: Successfully deleted song with ID: %d\n", id)
+    return nil
+}
+
+// GetDeletedSongs retrieves songs from the trash, most recently deleted first
+func (repo *MemorySongRepository) GetDeletedSongs(page int, limit int) ([]models.Song, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    deleted := make([]models.Song, 0)
+    for _, song := range repo.songs {
+        if song.DeletedAt.Valid {
+            deleted = append(deleted, song)
+        }
+    }
+    sort.Slice(deleted, func(i, j int) bool {
+        if !deleted[i].DeletedAt.Time.Equal(deleted[j].DeletedAt.Time) {
+            return deleted[i].DeletedAt.Time.After(deleted[j].DeletedAt.Time)
+        }
+        return deleted[i].ID < deleted[j].ID
+    })
+    return paginate(deleted, page, limit), nil
+}
+
+// RestoreSong restores a song from the trash
+func (repo *MemorySongRepository) RestoreSong(id uint) (*models.Song, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    song, ok := repo.songs[id]
+    if !ok || !song.DeletedAt.Valid {
+        log.Printf("ERROR: Song with ID: %d not found in trash\n", id)
+        return nil, ErrSongNotFound
+    }
+    if _, ok := repo.findLocked(song.Group, song.Song); ok {
+        return nil, ErrSongExists
+    }
+    song.DeletedAt = gorm.DeletedAt{}
+    repo.songs[id] = song
+    log.Printf("INFO: Successfully restored song with ID: %d\n", id)
+    return &song, nil
+}
+
+// PurgeSong permanently deletes a song
+func (repo *MemorySongRepository) PurgeSong(id uint) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if _, ok := repo.songs[id]; !ok {
+        log.Printf("ERROR: Song with ID: %d not found for purging\n", id)
+        return ErrSongNotFound
+    }
+    delete(repo.songs, id)
+    delete(repo.translations, id)
+    repo.removeSongTracksLocked(id)
+    delete(repo.credits, id)
+    delete(repo.songGenres, id)
+    delete(repo.songTags, id)
+    log.Printf("INFO: Successfully purged song with ID: %d\n", id)
+    return nil
+}
+
+// GetTranslations retrieves all translations of a song ordered by language code
+func (repo *MemorySongRepository) GetTranslations(songID uint) ([]models.Translation, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    translations := make([]models.Translation, 0, len(repo.translations[songID]))
+    for _, translation := range repo.translations[songID] {
+        translations = append(translations, translation)
+    }
+    sort.Slice(translations, func(i, j int) bool { return translations[i].Language < translations[j].Language })
+    return translations, nil
+}
+
+// GetTranslation retrieves the translation of a song into the given language
+func (repo *MemorySongRepository) GetTranslation(songID uint, language string) (*models.Translation, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    translation, ok := repo.translations[songID][language]
+    if !ok {
+        log.Printf("ERROR: Translation of song ID: %d into %s not found\n", songID, language)
+        return nil, ErrTranslationNotFound
+    }
+    return &translation, nil
+}
+
+// SaveTranslation adds a translation or replaces the existing translation into the same language
+func (repo *MemorySongRepository) SaveTranslation(translation *models.Translation) (*models.Translation, bool, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if song, ok := repo.songs[translation.SongID]; !ok || song.DeletedAt.Valid {
+        return nil, false, ErrSongNotFound
+    }
+    byLanguage, ok := repo.translations[translation.SongID]
+    if !ok {
+        byLanguage = make(map[string]models.Translation)
+        repo.translations[translation.SongID] = byLanguage
+    }
+    now := time.Now()
+    existing, exists := byLanguage[translation.Language]
+    if exists {
+        translation.ID = existing.ID
+        translation.CreatedAt = existing.CreatedAt
+    } else {
+        translation.ID = repo.nextTranslationID
+        translation.CreatedAt = now
+        repo.nextTranslationID++
+    }
+    translation.UpdatedAt = now
+    translation.Sections = models.ParseSections(translation.Text)
+    byLanguage[translation.Language] = *translation
+    log.Printf("INFO: Successfully saved %s translation of song ID: %d\n", translation.Language, translation.SongID)
+    return translation, !exists, nil
+}
+
+// DeleteTranslation deletes the translation of a song into the given language
+func (repo *MemorySongRepository) DeleteTranslation(songID uint, language string) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if _, ok := repo.translations[songID][language]; !ok {
+        log.Printf("ERROR: Translation of song ID: %d into %s not found for deletion\n", songID, language)
+        return ErrTranslationNotFound
+    }
+    delete(repo.translations[songID], language)
+    log.Printf("INFO: Successfully deleted %s translation of song ID: %d\n", language, songID)
+    return nil
+}
+
+// paginate возвращает страницу page размером limit из отсортированного списка
+func paginate(songs []models.Song, page int, limit int) []models.Song {
+    offset := (page - 1) * limit
+    if offset >= len(songs) {
+        return []models.Song{}
+    }
+    end := offset + limit
+    if end > len(songs) {
+        end = len(songs)
+    }
+    return songs[offset:end]
+}
+
+// filterLocked возвращает неудалённые песни, подходящие под фильтр, в произвольном порядке
+func (repo *MemorySongRepository) filterLocked(filter SongFilter) []models.Song {
+    sets := filterSets{aliased: repo.aliasedArtistsLocked(filter.Group)}
+    if filter.Credited != "" || filter.CreditRole != "" {
+        sets.credited = repo.creditedSongsLocked(filter.Credited, filter.CreditRole)
+    }
+    if filter.GenreID != 0 {
+        sets.genre = repo.genreSongsLocked(filter.GenreID)
+    }
+    if len(filter.Tags) > 0 {
+        sets.tagged = repo.taggedSongsLocked(filter.Tags)
+    }
+    matched := make([]models.Song, 0, len(repo.songs))
+    for _, song := range repo.songs {
+        if !song.DeletedAt.Valid && matchesFilter(song, filter, sets) {
+            matched = append(matched, song)
+        }
+    }
+    return matched
+}
+
+// filterSets — заранее вычисленные для фильтра множества: исполнители, псевдоним которых содержит
+// строку фильтра по группе, и песни с подходящими участниками, жанром и тегами (nil — такого фильтра нет)
+type filterSets struct {
+    aliased  map[uint]bool
+    credited map[uint]bool
+    genre    map[uint]bool
+    tagged   map[uint]bool
+}
+
+// matchesFilter повторяет семантику фильтров PostgreSQL-хранилища (ILIKE и диапазон дат релиза)
+func matchesFilter(song models.Song, filter SongFilter, sets filterSets) bool {
+    return (matchesName(song.Group, song.GroupKey, filter.Group) || (song.ArtistID != nil && sets.aliased[*song.ArtistID])) &&
+        (sets.credited == nil || sets.credited[song.ID]) &&
+        (sets.genre == nil || sets.genre[song.ID]) &&
+        (sets.tagged == nil || sets.tagged[song.ID]) &&
+        matchesName(song.Song, song.SongKey, filter.Song) &&
+        releasedWithin(song.ReleaseDate, filter.ReleasedFrom, filter.ReleasedBefore) &&
+        containsFold(song.Text, filter.Text) &&
+        containsFold(song.Link, filter.Link) &&
+        (filter.Language == "" || song.Language == filter.Language) &&
+        (filter.ArtistID == 0 || (song.ArtistID != nil && *song.ArtistID == filter.ArtistID)) &&
+        matchesMetadata(song.TrackMetadata, filter)
+}
+
+// matchesMetadata проверяет сведения о записи песни по фильтрам длительности, темпа, тональности, пометки и ISRC
+func matchesMetadata(metadata models.TrackMetadata, filter SongFilter) bool {
+    var duration *float64
+    if metadata.DurationSeconds != nil {
+        seconds := float64(*metadata.DurationSeconds)
+        duration = &seconds
+    }
+    return filter.Duration.Contains(duration) &&
+        filter.BPM.Contains(metadata.BPM) &&
+        (filter.Key == "" || (metadata.Key != nil && *metadata.Key == filter.Key)) &&
+        (filter.Mode == "" || (metadata.Mode != nil && *metadata.Mode == filter.Mode)) &&
+        (filter.Explicit == nil || (metadata.Explicit != nil && *metadata.Explicit == *filter.Explicit)) &&
+        (filter.ISRC == "" || (metadata.ISRC != nil && *metadata.ISRC == filter.ISRC))
+}
+
+// releasedWithin проверяет попадание даты релиза в полуинтервал [from, before); песни без даты не проходят фильтр по дате
+func releasedWithin(date models.ReleaseDate, from, before *time.Time) bool {
+    if from == nil && before == nil {
+        return true
+    }
+    if date.IsZero() {
+        return false
+    }
+    return (from == nil || !date.Date.Before(*from)) && (before == nil || date.Date.Before(*before))
+}
+
+// matchesName повторяет фильтр по названию PostgreSQL-хранилища: подстрока ищется в ключе поиска с транслитерацией,
+// а если в запросе нет букв и цифр — в самом названии без учёта регистра
+func matchesName(name, key, substr string) bool {
+    if substrKey := translit.Key(substr); substrKey != "" {
+        return strings.Contains(key, substrKey)
+    }
+    return containsFold(name, substr)
+}
+
+func containsFold(value, substr string) bool {
+    return substr == "" || strings.Contains(strings.ToLower(value), strings.ToLower(substr))
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+    "go-tunes/models"
+    "log"
+    "slices"
+    "sort"
+    "strings"
+    "time"
+)
+
+// CreateGenre saves a new genre in memory
+func (repo *MemorySongRepository) CreateGenre(genre *models.Genre) (*models.Genre, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if genre.ParentID != nil {
+        if _, ok := repo.genres[*genre.ParentID]; !ok {
+            return nil, ErrGenreNotFound
+        }
+    }
+    genre.NameKey = models.NameKey(genre.Name)
+    if _, ok := repo.findGenreLocked(genre.NameKey); ok {
+        return nil, ErrGenreExists
+    }
+    now := time.Now()
+    genre.ID = repo.nextGenreID
+    genre.CreatedAt = now
+    genre.UpdatedAt = now
+    repo.nextGenreID++
+    repo.genres[genre.ID] = *genre
+    log.Printf("INFO: Successfully saved genre with ID: %d\n", genre.ID)
+    return genre, nil
+}
+
+// GetGenres retrieves all genres ordered by name
+func (repo *MemorySongRepository) GetGenres() ([]models.Genre, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    genres := make([]models.Genre, 0, len(repo.genres))
+    for _, genre := range repo.genres {
+        genres = append(genres, genre)
+    }
+    sortGenres(genres)
+    return genres, nil
+}
+
+// GetGenreByID retrieves a genre by ID
+func (repo *MemorySongRepository) GetGenreByID(id uint) (*models.Genre, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    genre, ok := repo.genres[id]
+    if !ok {
+        return nil, ErrGenreNotFound
+    }
+    return &genre, nil
+}
+
+// FindGenre retrieves a genre by name, ignoring case, punctuation and transliteration
+func (repo *MemorySongRepository) FindGenre(name string) (*models.Genre, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    genre, ok := repo.findGenreLocked(models.NameKey(name))
+    if !ok {
+        return nil, ErrGenreNotFound
+    }
+    return &genre, nil
+}
+
+func (repo *MemorySongRepository) findGenreLocked(key string) (models.Genre, bool) {
+    for _, genre := range repo.genres {
+        if genre.NameKey == key {
+            return genre, true
+        }
+    }
+    return models.Genre{}, false
+}
+
+// UpdateGenre renames a genre or moves it under another parent
+func (repo *MemorySongRepository) UpdateGenre(genre *models.Genre) (*models.Genre, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    existing, ok := repo.genres[genre.ID]
+    if !ok {
+        return nil, ErrGenreNotFound
+    }
+    // Новый родитель не может быть самим жанром или его потомком: поднимаемся от родителя к корню
+    for parentID := genre.ParentID; parentID != nil; {
+        if *parentID == genre.ID {
+            return nil, ErrGenreCycle
+        }
+        parent, ok := repo.genres[*parentID]
+        if !ok {
+            return nil, ErrGenreNotFound
+        }
+        parentID = parent.ParentID
+    }
+    genre.NameKey = models.NameKey(genre.Name)
+    if other, ok := repo.findGenreLocked(genre.NameKey); ok && other.ID != genre.ID {
+        return nil, ErrGenreExists
+    }
+    genre.CreatedAt = existing.CreatedAt
+    genre.UpdatedAt = time.Now()
+    repo.genres[genre.ID] = *genre
+    log.Printf("INFO: Successfully updated genre with ID: %d\n", genre.ID)
+    return genre, nil
+}
+
+// DeleteGenre deletes a genre without subgenres; its songs lose the genre
+func (repo *MemorySongRepository) DeleteGenre(id uint) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if _, ok := repo.genres[id]; !ok {
+        return ErrGenreNotFound
+    }
+    for _, genre := range repo.genres {
+        if genre.ParentID != nil && *genre.ParentID == id {
+            return ErrGenreHasChildren
+        }
+    }
+    delete(repo.genres, id)
+    for songID, genreIDs := range repo.songGenres {
+        repo.songGenres[songID] = slices.DeleteFunc(genreIDs, func(genreID uint) bool { return genreID == id })
+    }
+    log.Printf("INFO: Successfully deleted genre with ID: %d\n", id)
+    return nil
+}
+
+// GetSongGenres retrieves the genres of a song ordered by name
+func (repo *MemorySongRepository) GetSongGenres(songID uint) ([]models.Genre, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    return repo.songGenresLocked(songID), nil
+}
+
+func (repo *MemorySongRepository) songGenresLocked(songID uint) []models.Genre {
+    genres := make([]models.Genre, 0, len(repo.songGenres[songID]))
+    for _, genreID := range repo.songGenres[songID] {
+        genres = append(genres, repo.genres[genreID])
+    }
+    sortGenres(genres)
+    return genres
+}
+
+// SetSongGenres replaces the genres of a song
+func (repo *MemorySongRepository) SetSongGenres(songID uint, genreIDs []uint) ([]models.Genre, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if song, ok := repo.songs[songID]; !ok || song.DeletedAt.Valid {
+        return nil, ErrSongNotFound
+    }
+    unique := make([]uint, 0, len(genreIDs))
+    for _, genreID := range genreIDs {
+        if _, ok := repo.genres[genreID]; !ok {
+            return nil, ErrGenreNotFound
+        }
+        if !slices.Contains(unique, genreID) {
+            unique = append(unique, genreID)
+        }
+    }
+    repo.songGenres[songID] = unique
+    return repo.songGenresLocked(songID), nil
+}
+
+// GetSongTags retrieves the tags of a song in alphabetical order
+func (repo *MemorySongRepository) GetSongTags(songID uint) ([]string, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    return append([]string{}, repo.songTags[songID]...), nil
+}
+
+// AddSongTags adds tags to a song; tags the song already has are kept
+func (repo *MemorySongRepository) AddSongTags(songID uint, tags []string) ([]string, error) {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    if song, ok := repo.songs[songID]; !ok || song.DeletedAt.Valid {
+        return nil, ErrSongNotFound
+    }
+    current := repo.songTags[songID]
+    for _, tag := range tags {
+        if !slices.Contains(current, tag) {
+            current = append(current, tag)
+        }
+    }
+    sort.Strings(current)
+    repo.songTags[songID] = current
+    return append([]string{}, current...), nil
+}
+
+// RemoveSongTag removes a tag from a song
+func (repo *MemorySongRepository) RemoveSongTag(songID uint, tag string) error {
+    repo.mu.Lock()
+    defer repo.mu.Unlock()
+
+    tags := repo.songTags[songID]
+    i := slices.Index(tags, tag)
+    if i < 0 {
+        return ErrTagNotFound
+    }
+    repo.songTags[songID] = slices.Delete(tags, i, i+1)
+    return nil
+}
+
+// GetTagCounts counts songs matching the filter by tag, most frequent tags first
+func (repo *MemorySongRepository) GetTagCounts(query TagQuery) ([]models.TagCount, error) {
+    repo.mu.RLock()
+    defer repo.mu.RUnlock()
+
+    counts := make(map[string]int64)
+    for _, song := range repo.filterLocked(query.Filter) {
+        for _, tag := range repo.songTags[song.ID] {
+            if strings.HasPrefix(tag, query.Prefix) {
+                counts[tag]++
+            }
+        }
+    }
+    result := make([]models.TagCount, 0, len(counts))
+    for tag, count := range counts {
+        result = append(result, models.TagCount{Tag: tag, Count: count})
+    }
+    sort.Slice(result, func(i, j int) bool {
+        if result[i].Count != result[j].Count {
+            return result[i].Count > result[j].Count
+        }
+        return result[i].Tag < result[j].Tag
+    })
+    return result[:min(query.Limit, len(result))], nil
+}
+
+// genreSongsLocked возвращает песни жанра id и всех его поджанров
+func (repo *MemorySongRepository) genreSongsLocked(id uint) map[uint]bool {
+    subtree := map[uint]bool{id: true}
+    // Дерево неглубокое: достраиваем поддерево, пока добавляются новые жанры
+    for grown := true; grown; {
+        grown = false
+        for _, genre := range repo.genres {
+            if genre.ParentID != nil && subtree[*genre.ParentID] && !subtree[genre.ID] {
+                subtree[genre.ID] = true
+                grown = true
+            }
+        }
+    }
+    songs := make(map[uint]bool)
+    for songID, genreIDs := range repo.songGenres {
+        for _, genreID := range genreIDs {
+            if subtree[genreID] {
+                songs[songID] = true
+            }
+        }
+    }
+    return songs
+}
+
+// taggedSongsLocked возвращает песни, у которых есть все перечисленные теги
+func (repo *MemorySongRepository) taggedSongsLocked(tags []string) map[uint]bool {
+    songs := make(map[uint]bool)
+    for songID, songTags := range repo.songTags {
+        if !slices.ContainsFunc(tags, func(tag string) bool { return !slices.Contains(songTags, tag) }) {
+            songs[songID] = true
+        }
+    }
+    return songs
+}
+
+// sortGenres упорядочивает жанры по названию, как PostgreSQL-хранилище
+func sortGenres(genres []models.Genre) {
+    sort.Slice(genres, func(i, j int) bool {
+        if genres[i].Name != genres[j].Name {
+            return genres[i].Name < genres[j].Name
+        }
+        return genres[i].ID < genres[j].ID
+    })
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "go-tunes/models"
+    "strings"
+    "time"
+)
+
+// ErrInvalidCursor возвращается, если курсор повреждён или получен при другой сортировке
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor указывает позицию в списке песен: значения полей сортировки песни на границе страницы.
+// Backward означает, что нужна страница перед этой песней, иначе — после неё.
+type Cursor struct {
+    Key      models.Song
+    Backward bool
+}
+
+// cursorPayload — содержимое курсора до кодирования в base64
+type cursorPayload struct {
+    Sort     string            `json:"s"`
+    Values   map[string]string `json:"v"`
+    Backward bool              `json:"b,omitempty"`
+}
+
+// EncodeCursor создаёт непрозрачный курсор, указывающий на песню song при сортировке sort
+func EncodeCursor(song models.Song, sort []SortField, backward bool) string {
+    values := make(map[string]string)
+    for _, field := range withTiebreaker(sort) {
+        if value, ok := cursorValue(song, field.Field); ok {
+            values[field.Field] = value
+        }
+    }
+    data, _ := json.Marshal(cursorPayload{Sort: sortSpec(sort), Values: values, Backward: backward})
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor разбирает курсор и проверяет, что он был получен при той же сортировке
+func DecodeCursor(token string, sort []SortField) (*Cursor, error) {
+    data, err := base64.RawURLEncoding.DecodeString(token)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+    }
+    var payload cursorPayload
+    if err := json.Unmarshal(data, &payload); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+    }
+    if payload.Sort != sortSpec(sort) {
+        return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidCursor, payload.Sort)
+    }
+
+    cursor := &Cursor{Backward: payload.Backward}
+    for _, field := range withTiebreaker(sort) {
+        value, ok := payload.Values[field.Field]
+        if err := setCursorValue(&cursor.Key, field.Field, value, ok); err != nil {
+            return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+        }
+    }
+    return cursor, nil
+}
+
+// sortSpec возвращает сортировку в виде строки параметра sort
+func sortSpec(sort []SortField) string {
+    parts := make([]string, 0, len(sort))
+    for _, field := range withTiebreaker(sort) {
+        if field.Desc {
+            parts = append(parts, "-"+field.Field)
+        } else {
+            parts = append(parts, field.Field)
+        }
+    }
+    return strings.Join(parts, ",")
+}
+
+func cursorValue(song models.Song, field string) (string, bool) {
+    switch field {
+    case "group":
+        return song.Group, true
+    case "song":
+        return song.Song, true
+    case "release_date":
+        if song.ReleaseDate.IsZero() {
+            return "", false
+        }
+        return song.ReleaseDate.Date.Format("2006-01-02"), true
+    case "created_at":
+        return song.CreatedAt.Format(time.RFC3339Nano), true
+    case "updated_at":
+        return song.UpdatedAt.Format(time.RFC3339Nano), true
+    default:
+        return fmt.Sprint(song.ID), true
+    }
+}
+
+func setCursorValue(song *models.Song, field, value string, present bool) error {
+    if !present && field != "release_date" {
+        return fmt.Errorf("missing value for %s", field)
+    }
+    var err error
+    switch field {
+    case "group":
+        song.Group = value
+    case "song":
+        song.Song = value
+    case "release_date":
+        if present {
+            var date time.Time
+            if date, err = time.Parse("2006-01-02", value); err == nil {
+                song.ReleaseDate = models.NewReleaseDate(date, models.PrecisionDay)
+            }
+        }
+    case "created_at":
+        song.CreatedAt, err = time.Parse(time.RFC3339Nano, value)
+    case "updated_at":
+        song.UpdatedAt, err = time.Parse(time.RFC3339Nano, value)
+    default:
+        var id uint
+        if _, err = fmt.Sscan(value, &id); err == nil {
+            song.ID = id
+        }
+    }
+    return err
+}
+
+// keysetCondition строит условие WHERE для страницы после (или перед) курсором.
+// Для полей f1..fn условие имеет вид (f1 ⊳ v1) OR (f1 = v1 AND f2 ⊳ v2) OR ...,
+// где ⊳ зависит от направления сортировки; песни без даты релиза считаются идущими последними.
+func keysetCondition(sort []SortField, cursor *Cursor) (string, []interface{}) {
+    var (
+        disjuncts []string
+        args      []interface{}
+        equal     []string
+        equalArgs []interface{}
+    )
+    for _, field := range withTiebreaker(sort) {
+        column := sortColumns[field.Field]
+        value := sqlCursorValue(cursor.Key, field.Field)
+        // Для курсора "назад" ищутся песни, идущие раньше, то есть сравнение меняется на противоположное
+        greater := field.Desc == cursor.Backward
+
+        var comparison string
+        var comparisonArgs []interface{}
+        switch {
+        case field.Field == "release_date" && value == nil:
+            // Курсор стоит на песне без даты: после неё только такие же песни, перед ней — все песни с датой
+            if !cursor.Backward {
+                comparison = "FALSE"
+            } else {
+                comparison = column + " IS NOT NULL"
+            }
+        case field.Field == "release_date" && !cursor.Backward:
+            comparison = fmt.Sprintf("(%s %s ? OR %s IS NULL)", column, operator(greater), column)
+            comparisonArgs = []interface{}{value}
+        default:
+            comparison = fmt.Sprintf("%s %s ?", column, operator(greater))
+            comparisonArgs = []interface{}{value}
+        }
+
+        disjunct := append(append([]string{}, equal...), comparison)
+        disjuncts = append(disjuncts, "("+strings.Join(disjunct, " AND ")+")")
+        args = append(append(args, equalArgs...), comparisonArgs...)
+
+        if value == nil {
+            equal = append(equal, column+" IS NULL")
+        } else {
+            equal = append(equal, column+" = ?")
+            equalArgs = append(equalArgs, value)
+        }
+    }
+    return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
+
+// songsBeforeCondition строит условие WHERE для песен, идущих не позже курсора страницы "вперёд":
+// песен перед позицией курсора и самой песни курсора. Такие песни образуют предыдущие страницы.
+func songsBeforeCondition(sort []SortField, cursor *Cursor) (string, []interface{}) {
+    condition, args := keysetCondition(sort, &Cursor{Key: cursor.Key, Backward: true})
+    return "(" + condition + " OR songs.id = ?)", append(args, cursor.Key.ID)
+}
+
+func operator(greater bool) string {
+    if greater {
+        return ">"
+    }
+    return "<"
+}
+
+func sqlCursorValue(song models.Song, field string) interface{} {
+    switch field {
+    case "group":
+        return song.Group
+    case "song":
+        return song.Song
+    case "release_date":
+        if song.ReleaseDate.IsZero() {
+            return nil
+        }
+        return *song.ReleaseDate.Date
+    case "created_at":
+        return song.CreatedAt
+    case "updated_at":
+        return song.UpdatedAt
+    default:
+        return song.ID
+    }
+}
+
+// trimKeysetPage отбрасывает лишнюю песню, выбранную для проверки наличия следующей страницы,
+// и восстанавливает прямой порядок для страницы перед курсором. Для страницы после курсора
+// наличие песен перед ней не известно и возвращается false (см. songsBeforeCondition).
+func trimKeysetPage(songs []models.Song, limit int, backward bool) ([]models.Song, bool, bool) {
+    more := len(songs) > limit
+    if more {
+        songs = songs[:limit]
+    }
+    if !backward {
+        return songs, more, false
+    }
+    for i, j := 0, len(songs)-1; i < j; i, j = i+1, j-1 {
+        songs[i], songs[j] = songs[j], songs[i]
+    }
+    return songs, true, more
+}
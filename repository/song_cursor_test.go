@@ -0,0 +1,118 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+    created := time.Date(2024, time.March, 1, 12, 30, 0, 123456789, time.UTC)
+    released := models.NewReleaseDate(time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC), models.PrecisionDay)
+    song := models.Song{ID: 42, Group: "Muse", Song: "Uprising", ReleaseDate: released, CreatedAt: created, UpdatedAt: created}
+
+    tests := []struct {
+        name     string
+        sort     []SortField
+        song     models.Song
+        backward bool
+        want     models.Song
+    }{
+        {"default sort", nil, song, false, models.Song{ID: 42}},
+        {"names", []SortField{{Field: "group"}, {Field: "song", Desc: true}}, song, true, models.Song{ID: 42, Group: "Muse", Song: "Uprising"}},
+        {"release date", []SortField{{Field: "release_date", Desc: true}}, song, false, models.Song{ID: 42, ReleaseDate: released}},
+        {"missing release date", []SortField{{Field: "release_date"}}, models.Song{ID: 7}, false, models.Song{ID: 7}},
+        {"timestamps", []SortField{{Field: "created_at"}, {Field: "updated_at"}}, song, false, models.Song{ID: 42, CreatedAt: created, UpdatedAt: created}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            token := EncodeCursor(tt.song, tt.sort, tt.backward)
+            cursor, err := DecodeCursor(token, tt.sort)
+            if err != nil {
+                t.Fatalf("DecodeCursor() error = %v", err)
+            }
+            if cursor.Backward != tt.backward {
+                t.Errorf("Backward = %v, want %v", cursor.Backward, tt.backward)
+            }
+            if !reflect.DeepEqual(cursor.Key, tt.want) {
+                t.Errorf("Key = %+v, want %+v", cursor.Key, tt.want)
+            }
+        })
+    }
+}
+
+func TestDecodeCursorErrors(t *testing.T) {
+    sort := []SortField{{Field: "group"}}
+    valid := EncodeCursor(models.Song{ID: 1, Group: "Muse"}, sort, false)
+    tests := []struct {
+        name  string
+        token string
+        sort  []SortField
+    }{
+        {"not base64", "!!!", sort},
+        {"not json", "bm90IGpzb24", sort},
+        {"other sort", valid, []SortField{{Field: "group", Desc: true}}},
+        {"missing value", EncodeCursor(models.Song{ID: 1}, nil, false), sort},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := DecodeCursor(tt.token, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+                t.Errorf("DecodeCursor() error = %v, want ErrInvalidCursor", err)
+            }
+        })
+    }
+}
+
+func TestKeysetCondition(t *testing.T) {
+    sort := []SortField{{Field: "group"}, {Field: "release_date", Desc: true}}
+    released := models.NewReleaseDate(time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC), models.PrecisionDay)
+    cursor := &Cursor{Key: models.Song{ID: 3, Group: "Muse", ReleaseDate: released}}
+
+    condition, args := keysetCondition(sort, cursor)
+    wantCondition := `((songs."group" > ?) OR (songs."group" = ? AND (songs.release_date < ? OR songs.release_date IS NULL)) OR ` +
+        `(songs."group" = ? AND songs.release_date = ? AND songs.id > ?))`
+    if condition != wantCondition {
+        t.Errorf("condition = %s, want %s", condition, wantCondition)
+    }
+    wantArgs := []interface{}{"Muse", "Muse", *released.Date, "Muse", *released.Date, uint(3)}
+    if !reflect.DeepEqual(args, wantArgs) {
+        t.Errorf("args = %v, want %v", args, wantArgs)
+    }
+}
+
+func TestSongsBeforeCondition(t *testing.T) {
+    cursor := &Cursor{Key: models.Song{ID: 3, Group: "Muse"}}
+
+    condition, args := songsBeforeCondition([]SortField{{Field: "group"}}, cursor)
+    wantCondition := `(((songs."group" < ?) OR (songs."group" = ? AND songs.id < ?)) OR songs.id = ?)`
+    if condition != wantCondition {
+        t.Errorf("condition = %s, want %s", condition, wantCondition)
+    }
+    wantArgs := []interface{}{"Muse", "Muse", uint(3), uint(3)}
+    if !reflect.DeepEqual(args, wantArgs) {
+        t.Errorf("args = %v, want %v", args, wantArgs)
+    }
+}
+
+func TestKeysetPageHasPrev(t *testing.T) {
+    sorted := []models.Song{{ID: 1}, {ID: 2}, {ID: 3}}
+    tests := []struct {
+        name string
+        key  uint
+        want bool
+    }{
+        {"cursor before the first song", 0, false},
+        {"cursor on the first song", 1, true},
+        {"cursor in the middle", 2, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            _, _, hasPrev := keysetPage(sorted, nil, &Cursor{Key: models.Song{ID: tt.key}}, 2)
+            if hasPrev != tt.want {
+                t.Errorf("keysetPage() hasPrev = %v, want %v", hasPrev, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+    "go-tunes/fuzzy"
+    "go-tunes/models"
+    "sort"
+)
+
+// SongMatch — песня, похожая на искомую пару группа+песня, с оценкой сходства
+type SongMatch struct {
+    Song  models.Song
+    Score fuzzy.Score
+}
+
+// rankMatches оценивает сходство кандидатов с искомой парой и возвращает не более limit лучших
+func rankMatches(candidates []models.Song, group, song string, limit int) []SongMatch {
+    matches := make([]SongMatch, 0, len(candidates))
+    for _, candidate := range candidates {
+        matches = append(matches, SongMatch{Song: candidate, Score: fuzzy.Compare(group, song, candidate.Group, candidate.Song)})
+    }
+    sort.SliceStable(matches, func(i, j int) bool {
+        if matches[i].Score.Total() != matches[j].Score.Total() {
+            return matches[i].Score.Total() > matches[j].Score.Total()
+        }
+        return matches[i].Song.ID < matches[j].Song.ID
+    })
+    if len(matches) > limit {
+        matches = matches[:limit]
+    }
+    return matches
+}
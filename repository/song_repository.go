@@ -1,15 +1,22 @@
 package repository
 
 import (
-    "log"
+    "errors"
+    "go-tunes/fuzzy"
     "go-tunes/models"
+    "go-tunes/translit"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "log"
+    "strings"
 )
 
 type SongRepository struct {
     DB *gorm.DB
 }
 
+var _ Store = (*SongRepository)(nil)
+
 func NewSongRepository(db *gorm.DB) *SongRepository {
     log.Println("INFO: Creating new SongRepository.")
     return &SongRepository{DB: db}
@@ -17,25 +24,248 @@ func NewSongRepository(db *gorm.DB) *SongRepository {
 
 // SaveSong saves a song to the database
 func (repo *SongRepository) SaveSong(song *models.Song) (*models.Song, error) {
-    if err := repo.DB.Create(song).Error; err != nil {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := assignArtist(tx, song); err != nil {
+            return err
+        }
+        return tx.Create(song).Error
+    })
+    if err != nil {
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return nil, ErrSongExists
+        }
         return nil, err
     }
     log.Printf("INFO: Successfully saved song with ID: %d\n", song.ID)
     return song, nil
 }
 
-// GetAllSongs retrieves all songs with pagination
-func (repo *SongRepository) GetAllSongs(page int, limit int) ([]models.Song, error) {
-    log.Printf("INFO: Retrieving all songs. Page: %d, Limit: %d\n", page, limit)
+// FirstOrCreateSong inserts a song unless the group/song pair already exists (INSERT ... ON CONFLICT DO NOTHING)
+func (repo *SongRepository) FirstOrCreateSong(song *models.Song) (*models.Song, bool, error) {
+    var created bool
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := assignArtist(tx, song); err != nil {
+            return err
+        }
+        result := tx.Clauses(clause.OnConflict{
+            Columns:     []clause.Column{{Name: "group"}, {Name: "song"}},
+            TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+            DoNothing:   true,
+        }).Create(song)
+        created = result.RowsAffected > 0
+        return result.Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to save song with group: %s, song: %s, error: %v\n", song.Group, song.Song, err)
+        return nil, false, err
+    }
+    if created {
+        log.Printf("INFO: Successfully saved song with ID: %d\n", song.ID)
+        return song, true, nil
+    }
+
+    // Песню уже добавил другой запрос — возвращаем существующую запись
+    existing, err := repo.GetSongByGroupAndSong(song.Group, song.Song)
+    if err != nil {
+        return nil, false, err
+    }
+    return existing, false, nil
+}
+
+// GetSongByGroupAndSong retrieves a song by its group and title; the group may be given by any name or alias of its artist
+func (repo *SongRepository) GetSongByGroupAndSong(group, song string) (*models.Song, error) {
+    log.Printf("INFO: Retrieving song with group: %s, song: %s\n", group, song)
+    // Группа может быть указана псевдонимом или другим написанием названия исполнителя
+    if artist, err := lookupArtist(repo.DB, models.ArtistKey(group)); err == nil {
+        group = artist.Name
+    }
+    var record models.Song
+    if err := repo.DB.Where("\"group\" = ? AND song = ?", group, song).First(&record).Error; err != nil {
+        log.Printf("INFO: Song with group: %s, song: %s not found, error: %v\n", group, song, err)
+        return nil, notFound(err)
+    }
+    return &record, nil
+}
+
+// nameCondition строит условие поиска подстроки в названии. Подстрока ищется в ключе поиска с транслитерацией
+// (триграммный индекс), поэтому "Kino" находит "Кино". Ключ состоит только из букв, цифр и пробелов, поэтому не требует экранирования. Если в подстроке нет букв и цифр, используется ILIKE по самому названию.
+func nameCondition(column, keyColumn, value string) (string, string) {
+    if key := translit.Key(value); key != "" {
+        return keyColumn + " LIKE ?", "%" + key + "%"
+    }
+    return column + " ILIKE ?", "%" + value + "%"
+}
+
+// filterSongs добавляет к запросу песен условия фильтра
+func filterSongs(query *gorm.DB, filter SongFilter) *gorm.DB {
+    if filter.Group != "" {
+        condition, pattern := nameCondition("\"group\"", "group_key", filter.Group)
+        if translit.Key(filter.Group) == "" {
+            query = query.Where(condition, pattern)
+        } else {
+            // Группу можно искать и по псевдониму исполнителя (например, прежнему названию)
+            query = query.Where("("+condition+" OR artist_id IN (SELECT id FROM artists WHERE EXISTS "+
+                "(SELECT 1 FROM jsonb_array_elements_text(alias_keys) AS alias_key WHERE alias_key LIKE ?)))", pattern, pattern)
+        }
+    }
+    if filter.Song != "" {
+        query = query.Where(nameCondition("song", "song_key", filter.Song))
+    }
+    if filter.Credited != "" || filter.CreditRole != "" {
+        condition, args := creditCondition(filter.Credited, filter.CreditRole)
+        query = query.Where(condition, args...)
+    }
+    if filter.ReleasedFrom != nil {
+        query = query.Where("release_date >= ?", *filter.ReleasedFrom)
+    }
+    if filter.ReleasedBefore != nil {
+        query = query.Where("release_date < ?", *filter.ReleasedBefore)
+    }
+    if filter.Text != "" {
+        query = query.Where("text ILIKE ?", "%"+filter.Text+"%")
+    }
+    if filter.Link != "" {
+        query = query.Where("link ILIKE ?", "%"+filter.Link+"%")
+    }
+    if filter.Language != "" {
+        query = query.Where("language = ?", filter.Language)
+    }
+    if filter.ArtistID != 0 {
+        query = query.Where("artist_id = ?", filter.ArtistID)
+    }
+    if filter.GenreID != 0 {
+        query = query.Where(genreCondition, filter.GenreID)
+    }
+    for _, tag := range filter.Tags {
+        query = query.Where("songs.id IN (SELECT song_id FROM song_tags WHERE tag = ?)", tag)
+    }
+    query = filterRange(query, "duration_seconds", filter.Duration)
+    query = filterRange(query, "bpm", filter.BPM)
+    if filter.Key != "" {
+        query = query.Where("musical_key = ?", filter.Key)
+    }
+    if filter.Mode != "" {
+        query = query.Where("mode = ?", filter.Mode)
+    }
+    if filter.Explicit != nil {
+        query = query.Where("explicit = ?", *filter.Explicit)
+    }
+    if filter.ISRC != "" {
+        query = query.Where("isrc = ?", filter.ISRC)
+    }
+    return query
+}
+
+// filterRange добавляет условия диапазона по колонке; строки с NULL под условия не попадают
+func filterRange(query *gorm.DB, column string, r Range) *gorm.DB {
+    if r.Min != nil {
+        query = query.Where(column+" >= ?", *r.Min)
+    }
+    if r.Max != nil {
+        query = query.Where(column+" <= ?", *r.Max)
+    }
+    return query
+}
+
+// BackfillDerivedFields fills search keys, lyric sections and lyric language for songs stored before these columns were introduced
+func (repo *SongRepository) BackfillDerivedFields() error {
     var songs []models.Song
-    offset := (page - 1) * limit
+    updated := 0
+    result := repo.DB.Unscoped().Where("group_key IS NULL OR song_key IS NULL OR sections IS NULL OR language IS NULL").
+        FindInBatches(&songs, 500, func(tx *gorm.DB, batch int) error {
+            for i := range songs {
+                songs[i].UpdateDerivedFields()
+                err := repo.DB.Unscoped().Model(&models.Song{}).Where("id = ?", songs[i].ID).
+                    UpdateColumns(map[string]interface{}{
+                        "group_key": songs[i].GroupKey,
+                        "song_key":  songs[i].SongKey,
+                        "sections":  songs[i].Sections,
+                        "language":  songs[i].Language,
+                    }).Error
+                if err != nil {
+                    return err
+                }
+            }
+            updated += len(songs)
+            return nil
+        })
+    if result.Error != nil {
+        return result.Error
+    }
+    if updated > 0 {
+        log.Printf("INFO: Backfilled derived fields for %d songs.\n", updated)
+    }
+    return nil
+}
+
+// similarCandidatesFactor — во сколько раз больше кандидатов выбирается по триграммам для точной оценки сходства
+const similarCandidatesFactor = 4
 
-    if err := repo.DB.Limit(limit).Offset(offset).Find(&songs).Error; err != nil {
-        log.Printf("ERROR: Failed to retrieve songs. Page: %d, Limit: %d, error: %v\n", page, limit, err)
+// FindSimilarSongs finds songs whose normalized group and title are similar to the given ones, across transliterations.
+// Candidates are preselected with the trigram index on the search keys and then ranked by edit distance.
+func (repo *SongRepository) FindSimilarSongs(group, song string, limit int) ([]SongMatch, error) {
+    log.Printf("INFO: Looking for songs similar to group: %s, song: %s\n", group, song)
+    key := fuzzy.Normalize(group + " " + song)
+    var candidates []models.Song
+    err := repo.DB.Where("(group_key || ' ' || song_key) % ?", key).
+        Order(clause.Expr{SQL: "similarity(group_key || ' ' || song_key, ?) DESC, id", Vars: []interface{}{key}}).
+        Limit(limit * similarCandidatesFactor).Find(&candidates).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to look up similar songs, error: %v\n", err)
         return nil, err
     }
-    log.Printf("INFO: Successfully retrieved %d songs.\n", len(songs))
-    return songs, nil
+    return rankMatches(candidates, group, song, limit), nil
+}
+
+// GetAllSongs retrieves songs matching the filter with sorting and either offset or keyset (cursor) pagination
+func (repo *SongRepository) GetAllSongs(songQuery SongQuery) (SongPage, error) {
+    filter, page, limit := songQuery.Filter, songQuery.Page, songQuery.Limit
+    log.Printf("INFO: Retrieving all songs. Page: %d, Limit: %d, Cursor: %t\n", page, limit, songQuery.Cursor != nil)
+
+    query := filterSongs(repo.DB.Model(&models.Song{}), filter)
+
+    // Session позволяет выполнить подсчёт и выборку на основе одного и того же набора условий
+    query = query.Session(&gorm.Session{})
+    result := SongPage{Songs: make([]models.Song, 0, limit)}
+    if err := query.Count(&result.Total).Error; err != nil {
+        log.Printf("ERROR: Failed to count songs, error: %v\n", err)
+        return SongPage{}, err
+    }
+
+    cursor := songQuery.Cursor
+    if cursor == nil {
+        offset := (page - 1) * limit
+        if err := query.Order(orderClause(songQuery.Sort, false)).Limit(limit).Offset(offset).Find(&result.Songs).Error; err != nil {
+            log.Printf("ERROR: Failed to retrieve songs. Page: %d, Limit: %d, error: %v\n", page, limit, err)
+            return SongPage{}, err
+        }
+        result.HasPrev = page > 1
+        result.HasNext = int64(offset+len(result.Songs)) < result.Total
+    } else {
+        // Выбираем на одну песню больше, чтобы узнать, есть ли следующая страница в направлении курсора
+        condition, args := keysetCondition(songQuery.Sort, cursor)
+        err := query.Where(condition, args...).
+            Order(orderClause(songQuery.Sort, cursor.Backward)).
+            Limit(limit + 1).Find(&result.Songs).Error
+        if err != nil {
+            log.Printf("ERROR: Failed to retrieve songs by cursor, error: %v\n", err)
+            return SongPage{}, err
+        }
+        result.Songs, result.HasNext, result.HasPrev = trimKeysetPage(result.Songs, limit, cursor.Backward)
+        if !cursor.Backward {
+            // Перед страницей "вперёд" есть песни, только если курсор стоит не перед первой песней
+            before, beforeArgs := songsBeforeCondition(songQuery.Sort, cursor)
+            var ids []uint
+            if err := query.Where(before, beforeArgs...).Limit(1).Pluck("songs.id", &ids).Error; err != nil {
+                log.Printf("ERROR: Failed to check songs before cursor, error: %v\n", err)
+                return SongPage{}, err
+            }
+            result.HasPrev = len(ids) > 0
+        }
+    }
+
+    log.Printf("INFO: Successfully retrieved %d of %d songs.\n", len(result.Songs), result.Total)
+    return result, nil
 }
 
 // GetSongByID retrieves a song by its ID
@@ -44,7 +274,7 @@ func (repo *SongRepository) GetSongByID(id uint) (*models.Song, error) {
     var song models.Song
     if err := repo.DB.First(&song, id).Error; err != nil {
         log.Printf("ERROR: Failed to retrieve song with ID: %d, error: %v\n", id, err)
-        return nil, err
+        return nil, notFound(err)
     }
     log.Printf("INFO: Successfully retrieved song with ID: %d\n", id)
     return &song, nil
@@ -53,21 +283,264 @@ func (repo *SongRepository) GetSongByID(id uint) (*models.Song, error) {
 // UpdateSong updates an existing song
 func (repo *SongRepository) UpdateSong(song *models.Song) (*models.Song, error) {
     log.Printf("INFO: Updating song with ID: %d\n", song.ID)
-    if err := repo.DB.Save(song).Error; err != nil {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := assignArtist(tx, song); err != nil {
+            return err
+        }
+        return tx.Save(song).Error
+    })
+    if err != nil {
         log.Printf("ERROR: Failed to update song with ID: %d, error: %v\n", song.ID, err)
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return nil, ErrSongExists
+        }
         return nil, err
     }
     log.Printf("INFO: Successfully updated song with ID: %d\n", song.ID)
     return song, nil
 }
 
+// UpdateSyncedLyrics writes only the synced_lyrics column, so concurrent edits of other fields are kept
+// and the song's save hooks and artist assignment are not rerun
+func (repo *SongRepository) UpdateSyncedLyrics(id uint, lyrics string) error {
+    log.Printf("INFO: Updating synced lyrics of song with ID: %d\n", id)
+    result := repo.DB.Model(&models.Song{}).Where("id = ?", id).
+        UpdateColumns(map[string]interface{}{"synced_lyrics": lyrics, "updated_at": gorm.Expr("now()")})
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to update synced lyrics of song with ID: %d, error: %v\n", id, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Song with ID: %d not found for synced lyrics update\n", id)
+        return ErrSongNotFound
+    }
+    log.Printf("INFO: Successfully updated synced lyrics of song with ID: %d\n", id)
+    return nil
+}
+
 // DeleteSong deletes a song by its ID
 func (repo *SongRepository) DeleteSong(id uint) error {
     log.Printf("INFO: Deleting song with ID: %d\n", id)
-    if err := repo.DB.Delete(&models.Song{}, id).Error; err != nil {
-        log.Printf("ERROR: Failed to delete song with ID: %d, error: %v\n", id, err)
-        return err
+    result := repo.DB.Delete(&models.Song{}, id)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to delete song with ID: %d, error: %v\n", id, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Song with ID: %d not found for deletion\n", id)
+        return ErrSongNotFound
     }
     log.Printf("INFO: Successfully deleted song with ID: %d\n", id)
     return nil
 }
+
+// GetDeletedSongs retrieves soft-deleted songs with pagination, most recently deleted first
+func (repo *SongRepository) GetDeletedSongs(page int, limit int) ([]models.Song, error) {
+    log.Printf("INFO: Retrieving deleted songs. Page: %d, Limit: %d\n", page, limit)
+    var songs []models.Song
+    offset := (page - 1) * limit
+    if err := repo.DB.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").
+        Limit(limit).Offset(offset).Find(&songs).Error; err != nil {
+        log.Printf("ERROR: Failed to retrieve deleted songs, error: %v\n", err)
+        return nil, err
+    }
+    log.Printf("INFO: Successfully retrieved %d deleted songs.\n", len(songs))
+    return songs, nil
+}
+
+// RestoreSong restores a soft-deleted song
+func (repo *SongRepository) RestoreSong(id uint) (*models.Song, error) {
+    log.Printf("INFO: Restoring song with ID: %d\n", id)
+    result := repo.DB.Unscoped().Model(&models.Song{}).
+        Where("id = ? AND deleted_at IS NOT NULL", id).
+        Update("deleted_at", nil)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to restore song with ID: %d, error: %v\n", id, result.Error)
+        if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+            return nil, ErrSongExists
+        }
+        return nil, result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Song with ID: %d not found in trash\n", id)
+        return nil, ErrSongNotFound
+    }
+    log.Printf("INFO: Successfully restored song with ID: %d\n", id)
+    return repo.GetSongByID(id)
+}
+
+// PurgeSong permanently deletes a song, whether it is in the trash or not
+func (repo *SongRepository) PurgeSong(id uint) error {
+    log.Printf("INFO: Purging song with ID: %d\n", id)
+    result := repo.DB.Unscoped().Delete(&models.Song{}, id)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to purge song with ID: %d, error: %v\n", id, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Song with ID: %d not found for purging\n", id)
+        return ErrSongNotFound
+    }
+    log.Printf("INFO: Successfully purged song with ID: %d\n", id)
+    return nil
+}
+
+// GetTranslations retrieves all translations of a song ordered by language code
+func (repo *SongRepository) GetTranslations(songID uint) ([]models.Translation, error) {
+    log.Printf("INFO: Retrieving translations of song ID: %d\n", songID)
+    var translations []models.Translation
+    if err := repo.DB.Where("song_id = ?", songID).Order("language").Find(&translations).Error; err != nil {
+        log.Printf("ERROR: Failed to retrieve translations of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return translations, nil
+}
+
+// GetTranslation retrieves the translation of a song into the given language
+func (repo *SongRepository) GetTranslation(songID uint, language string) (*models.Translation, error) {
+    var translation models.Translation
+    if err := repo.DB.Where("song_id = ? AND language = ?", songID, language).First(&translation).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            log.Printf("ERROR: Translation of song ID: %d into %s not found\n", songID, language)
+            return nil, ErrTranslationNotFound
+        }
+        log.Printf("ERROR: Failed to retrieve translation of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return &translation, nil
+}
+
+// SaveTranslation adds a translation or replaces the existing translation into the same language
+func (repo *SongRepository) SaveTranslation(translation *models.Translation) (*models.Translation, bool, error) {
+    created := false
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        var existing models.Translation
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+            Where("song_id = ? AND language = ?", translation.SongID, translation.Language).First(&existing).Error
+        switch {
+        case err == nil:
+            translation.ID = existing.ID
+            translation.CreatedAt = existing.CreatedAt
+            return tx.Save(translation).Error
+        case errors.Is(err, gorm.ErrRecordNotFound):
+            created = true
+            return tx.Create(translation).Error
+        default:
+            return err
+        }
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to save %s translation of song ID: %d, error: %v\n", translation.Language, translation.SongID, err)
+        if errors.Is(err, gorm.ErrForeignKeyViolated) {
+            return nil, false, ErrSongNotFound
+        }
+        return nil, false, err
+    }
+    log.Printf("INFO: Successfully saved %s translation of song ID: %d\n", translation.Language, translation.SongID)
+    return translation, created, nil
+}
+
+// DeleteTranslation deletes the translation of a song into the given language
+func (repo *SongRepository) DeleteTranslation(songID uint, language string) error {
+    result := repo.DB.Where("song_id = ? AND language = ?", songID, language).Delete(&models.Translation{})
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to delete %s translation of song ID: %d, error: %v\n", language, songID, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        log.Printf("ERROR: Translation of song ID: %d into %s not found for deletion\n", songID, language)
+        return ErrTranslationNotFound
+    }
+    log.Printf("INFO: Successfully deleted %s translation of song ID: %d\n", language, songID)
+    return nil
+}
+
+// notFound приводит ошибку GORM об отсутствии записи к ErrSongNotFound
+func notFound(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return ErrSongNotFound
+    }
+    return err
+}
+
+// searchRow — песня вместе с релевантностью, вычисленной ts_rank
+type searchRow struct {
+    models.Song
+    Rank float64
+}
+
+// verseRow — куплет песни, совпавший с поисковым запросом
+type verseRow struct {
+    SongID  uint
+    Verse   int
+    Snippet string
+}
+
+// SearchSongs performs a ranked full-text search over group, song title and lyrics using songs.search_vector
+func (repo *SongRepository) SearchSongs(searchQuery SearchQuery) ([]models.SearchHit, int64, error) {
+    log.Printf("INFO: Searching songs. Query: %q, Page: %d, Limit: %d\n", searchQuery.Query, searchQuery.Page, searchQuery.Limit)
+    if strings.TrimSpace(searchQuery.Query) == "" {
+        return nil, 0, ErrEmptySearchQuery
+    }
+
+    // Колонка search_vector строится в конфигурации языка песни (миграция 000013), поэтому запрос разбирается
+    // в той же конфигурации. Условие с song_search_query отбирает кандидатов по GIN-индексу
+    tsQuery := gorm.Expr("websearch_to_tsquery(song_search_config(songs.language), ?)", searchQuery.Query)
+    query := repo.DB.Model(&models.Song{}).
+        Where("songs.search_vector @@ song_search_query(?) AND songs.search_vector @@ ?", searchQuery.Query, tsQuery).
+        Session(&gorm.Session{})
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        log.Printf("ERROR: Failed to count search results, error: %v\n", err)
+        return nil, 0, err
+    }
+
+    var rows []searchRow
+    offset := (searchQuery.Page - 1) * searchQuery.Limit
+    err := query.Select("songs.*, ts_rank(songs.search_vector, ?) AS rank", tsQuery).
+        Order("rank DESC, songs.id").Limit(searchQuery.Limit).Offset(offset).Find(&rows).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to search songs, error: %v\n", err)
+        return nil, 0, err
+    }
+
+    hits := make([]models.SearchHit, len(rows))
+    ids := make([]uint, len(rows))
+    byID := make(map[uint]*models.SearchHit, len(rows))
+    for i, row := range rows {
+        hits[i] = models.SearchHit{Song: row.Song, Rank: row.Rank, Matches: []models.VerseMatch{}}
+        ids[i] = row.ID
+        byID[row.ID] = &hits[i]
+    }
+    if len(ids) == 0 {
+        return hits, total, nil
+    }
+
+    // Совпадения ищутся в частях песни из колонки sections, поэтому номера частей
+    // совпадают с номерами страниц GET /songs/{id}/verses
+    var verses []verseRow
+    err = repo.DB.Raw(`
+        SELECT v.song_id, v.verse, ts_headline(v.config, v.body, websearch_to_tsquery(v.config, ?)) AS snippet
+        FROM (
+            SELECT songs.id AS song_id, s.verse, song_search_config(songs.language) AS config,
+                array_to_string(ARRAY(SELECT jsonb_array_elements_text(s.section->'lines')), E'\n') AS body
+            FROM songs, jsonb_array_elements(songs.sections) WITH ORDINALITY AS s(section, verse)
+            WHERE songs.id IN ? AND jsonb_typeof(songs.sections) = 'array'
+        ) v
+        WHERE to_tsvector(v.config, v.body) @@ websearch_to_tsquery(v.config, ?)
+        ORDER BY v.song_id, v.verse`,
+        searchQuery.Query, ids, searchQuery.Query).
+        Scan(&verses).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to highlight matching verses, error: %v\n", err)
+        return nil, 0, err
+    }
+    for _, verse := range verses {
+        hit := byID[verse.SongID]
+        hit.Matches = append(hit.Matches, models.VerseMatch{Verse: verse.Verse, Snippet: verse.Snippet})
+    }
+
+    log.Printf("INFO: Found %d songs, returning %d.\n", total, len(hits))
+    return hits, total, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+    "sort"
+    "strings"
+    "unicode"
+)
+
+// ErrEmptySearchQuery возвращается, если в поисковом запросе нет ни одного слова
+var ErrEmptySearchQuery = errors.New("empty search query")
+
+// Разметка совпадений в сниппетах, совпадает с разметкой ts_headline по умолчанию
+const (
+    highlightStart = "<b>"
+    highlightStop  = "</b>"
+)
+
+// SearchQuery описывает полнотекстовый запрос в синтаксисе websearch_to_tsquery: слова, "фразы", -исключения, or
+type SearchQuery struct {
+    Query string
+    Page  int
+    Limit int
+}
+
+// searchTerms разбивает текст на слова в нижнем регистре
+func searchTerms(text string) []string {
+    return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+    })
+}
+
+// termMatches сравнивает слово текста с поисковым словом. Вместо стемминга совпадением считается общий префикс,
+// отличающийся от более длинного слова не больше чем на окончание
+func termMatches(word, term string) bool {
+    const maxSuffix = 3
+    if len([]rune(word)) < len([]rune(term)) {
+        word, term = term, word
+    }
+    return strings.HasPrefix(word, term) && len([]rune(word))-len([]rune(term)) <= maxSuffix
+}
+
+// containsTerm проверяет, встречается ли поисковое слово среди слов текста
+func containsTerm(words []string, term string) bool {
+    for _, word := range words {
+        if termMatches(word, term) {
+            return true
+        }
+    }
+    return false
+}
+
+// highlightTerms выделяет в тексте слова, совпавшие с поисковыми
+func highlightTerms(text string, terms []string) string {
+    var builder strings.Builder
+    runes := []rune(text)
+    for i := 0; i < len(runes); {
+        if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+            builder.WriteRune(runes[i])
+            i++
+            continue
+        }
+        j := i
+        for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+            j++
+        }
+        word := string(runes[i:j])
+        if containsTerm(terms, strings.ToLower(word)) {
+            builder.WriteString(highlightStart + word + highlightStop)
+        } else {
+            builder.WriteString(word)
+        }
+        i = j
+    }
+    return builder.String()
+}
+
+// matchSong упрощённо повторяет поиск PostgreSQL для хранилища в памяти: песня подходит, если каждое слово
+// запроса встречается в названии группы, песни или тексте. Совпадения в названиях весят больше совпадений в тексте.
+func matchSong(song models.Song, terms []string) (models.SearchHit, bool) {
+    titleWords := searchTerms(song.Group + " " + song.Song)
+    textWords := searchTerms(song.Text)
+
+    hit := models.SearchHit{Song: song, Matches: []models.VerseMatch{}}
+    for _, term := range terms {
+        inTitle, inText := containsTerm(titleWords, term), containsTerm(textWords, term)
+        if !inTitle && !inText {
+            return models.SearchHit{}, false
+        }
+        if inTitle {
+            hit.Rank += 1
+        }
+        if inText {
+            hit.Rank += 0.4
+        }
+    }
+    hit.Rank /= float64(len(terms))
+
+    // Часть песни считается совпавшей, если в ней встречаются все слова запроса
+    for i, section := range song.Sections {
+        verse := section.Text()
+        verseWords := searchTerms(verse)
+        matched := true
+        for _, term := range terms {
+            if !containsTerm(verseWords, term) {
+                matched = false
+                break
+            }
+        }
+        if matched {
+            hit.Matches = append(hit.Matches, models.VerseMatch{Verse: i + 1, Snippet: highlightTerms(verse, terms)})
+        }
+    }
+    return hit, true
+}
+
+// sortSearchHits упорядочивает результаты по убыванию релевантности, при равной релевантности — по ID
+func sortSearchHits(hits []models.SearchHit) {
+    sort.Slice(hits, func(i, j int) bool {
+        if hits[i].Rank != hits[j].Rank {
+            return hits[i].Rank > hits[j].Rank
+        }
+        return hits[i].Song.ID < hits[j].Song.ID
+    })
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+    "errors"
+    "fmt"
+    "go-tunes/models"
+    "strings"
+)
+
+// ErrInvalidSort возвращается при сортировке по полю, которого нет в белом списке
+var ErrInvalidSort = errors.New("invalid sort")
+
+// SortField описывает одно поле сортировки
+type SortField struct {
+    Field string
+    Desc  bool
+}
+
+// sortColumns — белый список полей сортировки и соответствующих им колонок таблицы songs
+var sortColumns = map[string]string{
+    "id":           "songs.id",
+    "group":        `songs."group"`,
+    "song":         "songs.song",
+    "release_date": "songs.release_date",
+    "created_at":   "songs.created_at",
+    "updated_at":   "songs.updated_at",
+}
+
+// ParseSort разбирает параметр сортировки вида "-release_date,group":
+// поля перечисляются через запятую, минус перед полем означает сортировку по убыванию.
+func ParseSort(value string) ([]SortField, error) {
+    var fields []SortField
+    seen := make(map[string]bool)
+    for _, part := range strings.Split(value, ",") {
+        part = strings.TrimSpace(part)
+        if part == "" {
+            continue
+        }
+        field := SortField{Field: part}
+        if strings.HasPrefix(part, "-") {
+            field = SortField{Field: part[1:], Desc: true}
+        } else if strings.HasPrefix(part, "+") {
+            field.Field = part[1:]
+        }
+        if _, ok := sortColumns[field.Field]; !ok {
+            return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, field.Field)
+        }
+        if seen[field.Field] {
+            return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidSort, field.Field)
+        }
+        seen[field.Field] = true
+        fields = append(fields, field)
+    }
+    return fields, nil
+}
+
+// withTiebreaker дополняет сортировку идентификатором, чтобы порядок был однозначным
+func withTiebreaker(fields []SortField) []SortField {
+    for _, field := range fields {
+        if field.Field == "id" {
+            return fields
+        }
+    }
+    return append(append([]SortField{}, fields...), SortField{Field: "id"})
+}
+
+// orderClause строит ORDER BY; песни без даты релиза всегда идут последними.
+// При reverse порядок полностью обращается (используется для выборки страницы перед курсором).
+func orderClause(fields []SortField, reverse bool) string {
+    parts := make([]string, 0, len(fields))
+    for _, field := range withTiebreaker(fields) {
+        direction, nulls := "ASC", "NULLS LAST"
+        if field.Desc != reverse {
+            direction = "DESC"
+        }
+        if reverse {
+            nulls = "NULLS FIRST"
+        }
+        parts = append(parts, fmt.Sprintf("%s %s %s", sortColumns[field.Field], direction, nulls))
+    }
+    return strings.Join(parts, ", ")
+}
+
+// compareSongs сравнивает песни по одному полю так же, как это делает PostgreSQL-хранилище
+func compareSongs(a, b models.Song, field string) int {
+    switch field {
+    case "group":
+        return strings.Compare(a.Group, b.Group)
+    case "song":
+        return strings.Compare(a.Song, b.Song)
+    case "release_date":
+        return compareReleaseDates(a.ReleaseDate, b.ReleaseDate)
+    case "created_at":
+        return a.CreatedAt.Compare(b.CreatedAt)
+    case "updated_at":
+        return a.UpdatedAt.Compare(b.UpdatedAt)
+    default:
+        return compareIDs(a.ID, b.ID)
+    }
+}
+
+func compareReleaseDates(a, b models.ReleaseDate) int {
+    switch {
+    case a.IsZero() && b.IsZero():
+        return 0
+    case a.IsZero():
+        return 1
+    case b.IsZero():
+        return -1
+    }
+    return a.Date.Compare(*b.Date)
+}
+
+func compareIDs(a, b uint) int {
+    switch {
+    case a < b:
+        return -1
+    case a > b:
+        return 1
+    }
+    return 0
+}
+
+// lessSongs сообщает, должна ли песня a идти раньше b при заданной сортировке
+func lessSongs(a, b models.Song, fields []SortField) bool {
+    for _, field := range withTiebreaker(fields) {
+        result := compareSongs(a, b, field.Field)
+        if result == 0 {
+            continue
+        }
+        // Песни без даты релиза идут последними при любом направлении сортировки
+        if field.Field == "release_date" && (a.ReleaseDate.IsZero() || b.ReleaseDate.IsZero()) {
+            return result < 0
+        }
+        if field.Desc {
+            return result > 0
+        }
+        return result < 0
+    }
+    return false
+}
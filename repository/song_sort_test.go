@@ -0,0 +1,34 @@
+package repository
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestParseSort(t *testing.T) {
+    tests := []struct {
+        value   string
+        want    []SortField
+        wantErr bool
+    }{
+        {"", nil, false},
+        {"-release_date,group", []SortField{{Field: "release_date", Desc: true}, {Field: "group"}}, false},
+        {" +song , -id ", []SortField{{Field: "song"}, {Field: "id", Desc: true}}, false},
+        {"group,,created_at", []SortField{{Field: "group"}, {Field: "created_at"}}, false},
+        {"text", nil, true},
+        {"group,-group", nil, true},
+    }
+    for _, tt := range tests {
+        got, err := ParseSort(tt.value)
+        if tt.wantErr {
+            if !errors.Is(err, ErrInvalidSort) {
+                t.Errorf("ParseSort(%q) error = %v, want ErrInvalidSort", tt.value, err)
+            }
+            continue
+        }
+        if err != nil || !reflect.DeepEqual(got, tt.want) {
+            t.Errorf("ParseSort(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+        }
+    }
+}
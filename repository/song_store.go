@@ -0,0 +1,122 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+    "time"
+)
+
+var (
+    // ErrSongNotFound возвращается хранилищем, когда песня не найдена
+    ErrSongNotFound = errors.New("song not found")
+    // ErrSongExists возвращается хранилищем при попытке сохранить уже существующую пару группа+песня
+    ErrSongExists = errors.New("song already exists")
+    // ErrTranslationNotFound возвращается хранилищем, когда у песни нет перевода на запрошенный язык
+    ErrTranslationNotFound = errors.New("translation not found")
+)
+
+// SongFilter описывает параметры фильтрации списка песен
+type SongFilter struct {
+    Group          string
+    Song           string
+    ReleasedFrom   *time.Time // Дата релиза не раньше указанной (включительно)
+    ReleasedBefore *time.Time // Дата релиза раньше указанной (не включительно)
+    Text           string
+    Link           string
+    Language       string            // Код языка текста, точное совпадение
+    ArtistID       uint              // Песни исполнителя; 0 — без отбора
+    Credited       string            // Подстрока названия или псевдонима участника песни
+    CreditRole     models.CreditRole // Роль участника; без Credited — песни с любым участником в этой роли
+    GenreID        uint              // Песни жанра и всех его поджанров; 0 — без отбора
+    Tags           []string          // Песни со всеми перечисленными тегами (в нормализованной записи)
+    Duration       Range             // Длительность в секундах
+    BPM            Range             // Темп в ударах в минуту
+    Key            string            // Тоника тональности в записи models.ParseKey
+    Mode           models.Mode       // Лад тональности
+    Explicit       *bool             // Пометка о ненормативной лексике; nil — без отбора
+    ISRC           string            // Код ISRC в записи models.ParseISRC
+}
+
+// Range — диапазон значений с включёнными границами; nil — граница не задана.
+// Песни без значения не проходят отбор по диапазону с заданной границей.
+type Range struct {
+    Min *float64
+    Max *float64
+}
+
+// IsSet сообщает, задана ли хотя бы одна граница диапазона
+func (r Range) IsSet() bool {
+    return r.Min != nil || r.Max != nil
+}
+
+// Contains проверяет попадание значения в диапазон
+func (r Range) Contains(value *float64) bool {
+    if !r.IsSet() {
+        return true
+    }
+    return value != nil && (r.Min == nil || *value >= *r.Min) && (r.Max == nil || *value <= *r.Max)
+}
+
+// SongQuery описывает запрос списка песен: фильтры, сортировку и страницу.
+// Если задан Cursor, страница выбирается относительно него (keyset-пагинация), а Page не используется.
+type SongQuery struct {
+    Filter SongFilter
+    Sort   []SortField
+    Page   int
+    Limit  int
+    Cursor *Cursor
+}
+
+// SongPage — страница списка песен
+type SongPage struct {
+    Songs   []models.Song
+    Total   int64 // Общее количество песен, подходящих под фильтры
+    HasNext bool  // После страницы есть ещё песни
+    HasPrev bool  // Перед страницей есть песни
+}
+
+// SongStore описывает хранилище песен, с которым работают контроллеры
+type SongStore interface {
+    SaveSong(song *models.Song) (*models.Song, error)
+    // FirstOrCreateSong сохраняет песню, если пары группа+песня ещё нет, иначе возвращает существующую запись.
+    // Второе значение сообщает, была ли создана новая запись.
+    FirstOrCreateSong(song *models.Song) (*models.Song, bool, error)
+    // GetAllSongs возвращает страницу песен вместе с общим количеством песен, подходящих под фильтры
+    GetAllSongs(query SongQuery) (SongPage, error)
+    GetSongByID(id uint) (*models.Song, error)
+    GetSongByGroupAndSong(group, song string) (*models.Song, error)
+    // FindSimilarSongs возвращает до limit песен с похожими названиями группы и песни, от самой похожей
+    FindSimilarSongs(group, song string, limit int) ([]SongMatch, error)
+    UpdateSong(song *models.Song) (*models.Song, error)
+    // UpdateSyncedLyrics заменяет только синхронизированный текст песни (LRC), не затрагивая остальные поля;
+    // пустая строка удаляет его
+    UpdateSyncedLyrics(id uint, lyrics string) error
+    // DeleteSong помещает песню в корзину (мягкое удаление)
+    DeleteSong(id uint) error
+    // GetDeletedSongs возвращает песни из корзины с пагинацией
+    GetDeletedSongs(page int, limit int) ([]models.Song, error)
+    // RestoreSong возвращает песню из корзины
+    RestoreSong(id uint) (*models.Song, error)
+    // PurgeSong окончательно удаляет песню, находится ли она в корзине или нет
+    PurgeSong(id uint) error
+    // SearchSongs выполняет полнотекстовый поиск и возвращает страницу результатов по убыванию релевантности
+    // вместе с общим количеством найденных песен
+    SearchSongs(query SearchQuery) ([]models.SearchHit, int64, error)
+    // GetTranslations возвращает все переводы песни, упорядоченные по коду языка
+    GetTranslations(songID uint) ([]models.Translation, error)
+    GetTranslation(songID uint, language string) (*models.Translation, error)
+    // SaveTranslation добавляет перевод или заменяет существующий перевод песни на тот же язык.
+    // Второе значение сообщает, был ли перевод добавлен.
+    SaveTranslation(translation *models.Translation) (*models.Translation, bool, error)
+    DeleteTranslation(songID uint, language string) error
+}
+
+// Store объединяет хранилища песен, исполнителей, альбомов, участников и жанров с тегами; его реализуют
+// SongRepository и MemorySongRepository. Контроллеры зависят только от нужных им хранилищ.
+type Store interface {
+    SongStore
+    ArtistStore
+    AlbumStore
+    CreditStore
+    TaxonomyStore
+}
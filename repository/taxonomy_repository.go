@@ -0,0 +1,252 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+    "log"
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// genreCondition отбирает песни жанра и всех его поджанров
+const genreCondition = "songs.id IN (SELECT song_id FROM song_genres WHERE genre_id IN (" +
+    "WITH RECURSIVE subtree AS (SELECT id FROM genres WHERE id = ? " +
+    "UNION ALL SELECT genres.id FROM genres JOIN subtree ON genres.parent_id = subtree.id) " +
+    "SELECT id FROM subtree))"
+
+// CreateGenre saves a new genre
+func (repo *SongRepository) CreateGenre(genre *models.Genre) (*models.Genre, error) {
+    if genre.ParentID != nil {
+        if _, err := repo.GetGenreByID(*genre.ParentID); err != nil {
+            return nil, err
+        }
+    }
+    if err := repo.DB.Create(genre).Error; err != nil {
+        log.Printf("ERROR: Failed to save genre %s, error: %v\n", genre.Name, err)
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return nil, ErrGenreExists
+        }
+        return nil, err
+    }
+    log.Printf("INFO: Successfully saved genre with ID: %d\n", genre.ID)
+    return genre, nil
+}
+
+// GetGenres retrieves all genres ordered by name
+func (repo *SongRepository) GetGenres() ([]models.Genre, error) {
+    var genres []models.Genre
+    if err := repo.DB.Order("name, id").Find(&genres).Error; err != nil {
+        log.Printf("ERROR: Failed to retrieve genres, error: %v\n", err)
+        return nil, err
+    }
+    return genres, nil
+}
+
+// GetGenreByID retrieves a genre by ID
+func (repo *SongRepository) GetGenreByID(id uint) (*models.Genre, error) {
+    var genre models.Genre
+    if err := repo.DB.First(&genre, id).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, ErrGenreNotFound
+        }
+        log.Printf("ERROR: Failed to retrieve genre with ID: %d, error: %v\n", id, err)
+        return nil, err
+    }
+    return &genre, nil
+}
+
+// FindGenre retrieves a genre by name, ignoring case, punctuation and transliteration
+func (repo *SongRepository) FindGenre(name string) (*models.Genre, error) {
+    var genre models.Genre
+    if err := repo.DB.Where("name_key = ?", models.NameKey(name)).First(&genre).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, ErrGenreNotFound
+        }
+        log.Printf("ERROR: Failed to look up genre %s, error: %v\n", name, err)
+        return nil, err
+    }
+    return &genre, nil
+}
+
+// UpdateGenre renames a genre or moves it under another parent
+func (repo *SongRepository) UpdateGenre(genre *models.Genre) (*models.Genre, error) {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        var existing models.Genre
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, genre.ID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return ErrGenreNotFound
+            }
+            return err
+        }
+        // Новый родитель не может быть самим жанром или его потомком: поднимаемся от родителя к корню
+        for parentID := genre.ParentID; parentID != nil; {
+            if *parentID == genre.ID {
+                return ErrGenreCycle
+            }
+            var parent models.Genre
+            if err := tx.First(&parent, *parentID).Error; err != nil {
+                if errors.Is(err, gorm.ErrRecordNotFound) {
+                    return ErrGenreNotFound
+                }
+                return err
+            }
+            parentID = parent.ParentID
+        }
+        genre.CreatedAt = existing.CreatedAt
+        err := tx.Save(genre).Error
+        if errors.Is(err, gorm.ErrDuplicatedKey) {
+            return ErrGenreExists
+        }
+        return err
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to update genre with ID: %d, error: %v\n", genre.ID, err)
+        return nil, err
+    }
+    log.Printf("INFO: Successfully updated genre with ID: %d\n", genre.ID)
+    return genre, nil
+}
+
+// DeleteGenre deletes a genre without subgenres; its songs lose the genre
+func (repo *SongRepository) DeleteGenre(id uint) error {
+    var children int64
+    if err := repo.DB.Model(&models.Genre{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+        return err
+    }
+    if children > 0 {
+        return ErrGenreHasChildren
+    }
+    result := repo.DB.Delete(&models.Genre{}, id)
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to delete genre with ID: %d, error: %v\n", id, result.Error)
+        if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+            return ErrGenreHasChildren
+        }
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return ErrGenreNotFound
+    }
+    log.Printf("INFO: Successfully deleted genre with ID: %d\n", id)
+    return nil
+}
+
+// GetSongGenres retrieves the genres of a song ordered by name
+func (repo *SongRepository) GetSongGenres(songID uint) ([]models.Genre, error) {
+    genres := []models.Genre{}
+    err := repo.DB.Joins("JOIN song_genres ON song_genres.genre_id = genres.id").
+        Where("song_genres.song_id = ?", songID).
+        Order("genres.name, genres.id").
+        Find(&genres).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to retrieve genres of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return genres, nil
+}
+
+// SetSongGenres replaces the genres of a song
+func (repo *SongRepository) SetSongGenres(songID uint, genreIDs []uint) ([]models.Genre, error) {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Select("id").First(&models.Song{}, songID).Error; err != nil {
+            return notFound(err)
+        }
+        links := make([]models.SongGenre, 0, len(genreIDs))
+        seen := make(map[uint]bool, len(genreIDs))
+        for _, genreID := range genreIDs {
+            if !seen[genreID] {
+                seen[genreID] = true
+                links = append(links, models.SongGenre{SongID: songID, GenreID: genreID})
+            }
+        }
+        if err := tx.Where("song_id = ?", songID).Delete(&models.SongGenre{}).Error; err != nil {
+            return err
+        }
+        if len(links) == 0 {
+            return nil
+        }
+        var found int64
+        if err := tx.Model(&models.Genre{}).Where("id IN ?", genreIDs).Count(&found).Error; err != nil {
+            return err
+        }
+        if int(found) != len(links) {
+            return ErrGenreNotFound
+        }
+        return tx.Create(&links).Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to set genres of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return repo.GetSongGenres(songID)
+}
+
+// GetSongTags retrieves the tags of a song in alphabetical order
+func (repo *SongRepository) GetSongTags(songID uint) ([]string, error) {
+    tags := []string{}
+    if err := repo.DB.Model(&models.SongTag{}).Where("song_id = ?", songID).Order("tag").Pluck("tag", &tags).Error; err != nil {
+        log.Printf("ERROR: Failed to retrieve tags of song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return tags, nil
+}
+
+// AddSongTags adds tags to a song; tags the song already has are kept
+func (repo *SongRepository) AddSongTags(songID uint, tags []string) ([]string, error) {
+    err := repo.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Select("id").First(&models.Song{}, songID).Error; err != nil {
+            return notFound(err)
+        }
+        rows := make([]models.SongTag, 0, len(tags))
+        for _, tag := range tags {
+            rows = append(rows, models.SongTag{SongID: songID, Tag: tag})
+        }
+        if len(rows) == 0 {
+            return nil
+        }
+        return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+    })
+    if err != nil {
+        log.Printf("ERROR: Failed to add tags to song ID: %d, error: %v\n", songID, err)
+        return nil, err
+    }
+    return repo.GetSongTags(songID)
+}
+
+// RemoveSongTag removes a tag from a song
+func (repo *SongRepository) RemoveSongTag(songID uint, tag string) error {
+    result := repo.DB.Where("song_id = ? AND tag = ?", songID, tag).Delete(&models.SongTag{})
+    if result.Error != nil {
+        log.Printf("ERROR: Failed to remove tag from song ID: %d, error: %v\n", songID, result.Error)
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return ErrTagNotFound
+    }
+    return nil
+}
+
+// GetTagCounts counts songs matching the filter by tag, most frequent tags first
+func (repo *SongRepository) GetTagCounts(tagQuery TagQuery) ([]models.TagCount, error) {
+    songs := filterSongs(repo.DB.Model(&models.Song{}).Select("songs.id"), tagQuery.Filter)
+    query := repo.DB.Model(&models.SongTag{}).
+        Select("tag, count(*) AS count").
+        Where("song_id IN (?)", songs)
+    if tagQuery.Prefix != "" {
+        query = query.Where("tag LIKE ?", escapeLike(tagQuery.Prefix)+"%")
+    }
+    counts := []models.TagCount{}
+    err := query.Group("tag").Order("count DESC, tag").Limit(tagQuery.Limit).Scan(&counts).Error
+    if err != nil {
+        log.Printf("ERROR: Failed to count tags, error: %v\n", err)
+        return nil, err
+    }
+    return counts, nil
+}
+
+// escapeLike экранирует служебные символы шаблона LIKE
+func escapeLike(value string) string {
+    return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+    "errors"
+    "go-tunes/models"
+)
+
+var (
+    // ErrGenreNotFound возвращается хранилищем, когда жанр не найден
+    ErrGenreNotFound = errors.New("genre not found")
+    // ErrGenreExists возвращается при попытке сохранить жанр с названием, которое уже есть в дереве
+    ErrGenreExists = errors.New("genre already exists")
+    // ErrGenreHasChildren возвращается при попытке удалить жанр с дочерними жанрами
+    ErrGenreHasChildren = errors.New("genre has subgenres")
+    // ErrGenreCycle возвращается, если новый родитель жанра — сам жанр или один из его потомков
+    ErrGenreCycle = errors.New("genre cannot be moved under itself or its subgenre")
+    // ErrTagNotFound возвращается хранилищем, когда у песни нет тега
+    ErrTagNotFound = errors.New("tag not found")
+)
+
+// TagQuery описывает запрос количества песен по тегам: теги считаются среди неудалённых песен, подходящих
+// под фильтр, от самых частых
+type TagQuery struct {
+    Filter SongFilter
+    Prefix string // Начало тега в нормализованной записи
+    Limit  int
+}
+
+// TaxonomyStore описывает хранилище дерева жанров и тегов песен
+type TaxonomyStore interface {
+    CreateGenre(genre *models.Genre) (*models.Genre, error)
+    // GetGenres возвращает все жанры списком, упорядоченным по названию
+    GetGenres() ([]models.Genre, error)
+    GetGenreByID(id uint) (*models.Genre, error)
+    // FindGenre ищет жанр по названию без учёта регистра, пунктуации и транслитерации
+    FindGenre(name string) (*models.Genre, error)
+    // UpdateGenre переименовывает жанр или переносит его к другому родителю
+    UpdateGenre(genre *models.Genre) (*models.Genre, error)
+    // DeleteGenre удаляет жанр без дочерних жанров; песни остаются, но теряют этот жанр
+    DeleteGenre(id uint) error
+    // GetSongGenres возвращает жанры песни, упорядоченные по названию
+    GetSongGenres(songID uint) ([]models.Genre, error)
+    // SetSongGenres заменяет жанры песни
+    SetSongGenres(songID uint, genreIDs []uint) ([]models.Genre, error)
+    // GetSongTags возвращает теги песни по алфавиту
+    GetSongTags(songID uint) ([]string, error)
+    // AddSongTags добавляет песне нормализованные теги и возвращает все её теги
+    AddSongTags(songID uint, tags []string) ([]string, error)
+    RemoveSongTag(songID uint, tag string) error
+    GetTagCounts(query TagQuery) ([]models.TagCount, error)
+}
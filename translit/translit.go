@@ -0,0 +1,73 @@
+// Package translit строит ключи поиска, одинаковые для написаний названия кириллицей и латиницей
+// в разных системах транслитерации (ГОСТ 7.79, научная, ИКАО, бытовая) и с диакритикой или без неё:
+// Key("Кино") == Key("Kino"), Key("Щелкунчик") == Key("Shchelkunchik") == Key("Ščelkunčik").
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// cyrillic переводит кириллические буквы (русские и украинские) в латиницу
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "c",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "i", 'є': "e", 'ґ': "g",
+}
+
+// diacritics раскрывает латинские буквы с диакритикой из научной системы и ГОСТ 7.79 (система А)
+// до удаления остальных диакритических знаков
+var diacritics = map[rune]string{
+	'ž': "zh", 'č': "ch", 'š': "sh", 'ŝ': "shch", 'û': "yu", 'â': "ya", 'ǎ': "ya",
+}
+
+// folds сводит к одному написанию буквосочетания, которыми разные системы передают одну кириллическую букву.
+// Замены применяются по порядку, поэтому более длинные сочетания идут первыми.
+var folds = strings.NewReplacer(
+	"shch", "sh", "shh", "sh", "sch", "sh", // щ
+	"kh", "h", // х
+	"x", "ks",
+	"tz", "c", "ts", "c", "cz", "c", // ц
+	"yu", "iu", "ju", "iu", // ю
+	"ya", "ia", "ja", "ia", // я
+	"yo", "e", "jo", "e", "ye", "e", "je", "e", // ё, е
+	"y", "i", "j", "i", // й, ы
+	"w", "v",
+)
+
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Key возвращает ключ поиска: латиница в нижнем регистре без диакритики и пунктуации,
+// в которой варианты транслитерации сведены к одному написанию
+func Key(value string) string {
+	value = strings.ToLower(norm.NFC.String(value))
+
+	var builder strings.Builder
+	for _, r := range value {
+		if latin, ok := cyrillic[r]; ok {
+			builder.WriteString(latin)
+		} else if latin, ok := diacritics[r]; ok {
+			builder.WriteString(latin)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+
+	plain, _, err := transform.String(stripMarks, builder.String())
+	if err != nil {
+		plain = builder.String()
+	}
+	words := strings.FieldsFunc(plain, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = folds.Replace(word)
+	}
+	return strings.Join(words, " ")
+}
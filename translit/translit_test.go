@@ -0,0 +1,49 @@
+package translit
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Кино", "kino"},
+		{"  KINO!!! ", "kino"},
+		{"Щелкунчик", "shelkunchik"},
+		{"Shchelkunchik", "shelkunchik"},
+		{"Ščelkunčik", "shelkunchik"},
+		{"Motörhead", "motorhead"},
+		{"AC/DC", "ac dc"},
+		{"Blink-182", "blink 182"},
+		{"Юрий Шевчук", "iurii shevchuk"},
+		{"Yuriy Shevchuk", "iurii shevchuk"},
+		{"Сплин", "splin"},
+		{"Ёлка", "elka"},
+		{"Yolka", "elka"},
+		{"Maxim", "maksim"},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.value); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestKeyMatchesSpellings(t *testing.T) {
+	spellings := [][]string{
+		{"Кино", "Kino"},
+		{"Щелкунчик", "Shchelkunchik", "Ščelkunčik"},
+		{"Цой", "Tsoi", "Coj", "Tzoy"},
+		{"Хохлома", "Khokhloma", "Hohloma"},
+		{"Юля", "Yulya", "Julja", "Iulia"},
+	}
+	for _, group := range spellings {
+		want := Key(group[0])
+		for _, spelling := range group[1:] {
+			if got := Key(spelling); got != want {
+				t.Errorf("Key(%q) = %q, want %q as for %q", spelling, got, want, group[0])
+			}
+		}
+	}
+}